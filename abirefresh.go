@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// explorerAPIURL is the Etherscan-family (Arbiscan) API endpoint used to fetch contract ABIs.
+const explorerAPIURL = "https://api.etherscan.io/v2/api"
+
+// controllerABIJSON is the subset of the Livepeer Controller ABI used to resolve contract targets.
+const controllerABIJSON = `[
+	{"type":"function","name":"getContract","stateMutability":"view","inputs":[{"name":"_id","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"event","name":"SetContractInfo","anonymous":false,"inputs":[{"name":"id","type":"bytes32","indexed":false},{"name":"contractAddress","type":"address","indexed":false},{"name":"gitCommitHash","type":"bytes20","indexed":false}]}
+]`
+
+var controllerABI = mustParseABI(controllerABIJSON)
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// expectedEvents lists the events each contract ABI must contain to be usable by the watcher.
+var expectedEvents = map[string][]string{
+	"BondingManager":  {"Reward", "TranscoderDeactivated"},
+	"RoundsManager":   {"NewRound"},
+	"TicketBroker":    {"WinningTicketRedeemed"},
+	"ServiceRegistry": {},
+}
+
+var explorerHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// fetchExplorerABI downloads the verified ABI of a contract from the block explorer API.
+func fetchExplorerABI(apiKey string, chainID uint64, address common.Address) (string, error) {
+	q := url.Values{}
+	q.Set("chainid", fmt.Sprint(chainID))
+	q.Set("module", "contract")
+	q.Set("action", "getabi")
+	q.Set("address", address.Hex())
+	q.Set("apikey", apiKey)
+	resp, err := explorerHTTPClient.Get(explorerAPIURL + "?" + q.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse explorer response: %v", err)
+	}
+	if result.Status != "1" {
+		return "", fmt.Errorf("explorer API error: %s: %s", result.Message, result.Result)
+	}
+	return result.Result, nil
+}
+
+// validateABI checks that an ABI contains all events the watcher relies on.
+func validateABI(name string, parsed abi.ABI) error {
+	for _, event := range expectedEvents[name] {
+		if _, ok := parsed.Events[event]; !ok {
+			return fmt.Errorf("%s ABI lacks the %s event", name, event)
+		}
+	}
+	return nil
+}
+
+// refreshABIs resolves the current implementation of every watched contract through the Controller
+// and reloads ABIs whose implementation changed. Fetched ABIs are cached on disk by address. When an
+// ABI changes, the watcher reconnects so subscriptions use the new event signatures.
+func (w *watcher) refreshABIs() {
+	updated := *w.abis
+	targets := []struct {
+		name   string
+		target *abi.ABI
+	}{
+		{"BondingManager", &updated.BondingManager},
+		{"RoundsManager", &updated.RoundsManager},
+		{"TicketBroker", &updated.TicketBroker},
+		{"ServiceRegistry", &updated.ServiceRegistry},
+	}
+	var changed []string
+	for _, t := range targets {
+		values, err := w.callContract(controllerABI, w.net.Contracts.Controller, "getContract", crypto.Keccak256Hash([]byte(t.name+"Target")))
+		if err != nil || len(values) == 0 {
+			w.log.Printf("failed to resolve %s implementation: %v", t.name, err)
+			continue
+		}
+		impl, _ := values[0].(common.Address)
+		if impl == (common.Address{}) || w.abiImplementations[t.name] == impl {
+			continue
+		}
+		parsed, err := w.loadImplementationABI(t.name, impl)
+		if err != nil {
+			w.log.Printf("failed to load %s ABI for implementation %s: %v", t.name, impl.Hex(), err)
+			continue
+		}
+		first := w.abiImplementations[t.name] == (common.Address{})
+		w.abiImplementations[t.name] = impl
+		if first && eventsEqual(t.target, &parsed) {
+			continue // The bundled ABI already matches the current implementation.
+		}
+		*t.target = parsed
+		changed = append(changed, fmt.Sprintf("%s (%s)", t.name, impl.Hex()))
+	}
+	if len(changed) == 0 {
+		return
+	}
+	w.abis = &updated
+	msg := fmt.Sprintf(tr("🔁 Contract ABI refreshed after an implementation change: %s."), strings.Join(changed, ", "))
+	w.log.Println(msg)
+	if w.opts.enableRPCAlerts {
+		w.alert("abi_refreshed", msg, 0x0099FF)
+	}
+	w.reconnectRequested = true
+}
+
+// loadImplementationABI returns the ABI of a contract implementation from the disk cache or the explorer.
+func (w *watcher) loadImplementationABI(name string, impl common.Address) (abi.ABI, error) {
+	cachePath := filepath.Join(w.opts.abiCacheDir, fmt.Sprintf("%s-%s.json", name, strings.ToLower(impl.Hex())))
+	raw, err := os.ReadFile(cachePath)
+	if err != nil {
+		fetched, err := fetchExplorerABI(w.opts.explorerAPIKey, w.net.ChainID, impl)
+		if err != nil {
+			return abi.ABI{}, err
+		}
+		raw = []byte(fetched)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+	if err := validateABI(name, parsed); err != nil {
+		return abi.ABI{}, err
+	}
+	if err := os.MkdirAll(w.opts.abiCacheDir, 0755); err == nil {
+		os.WriteFile(cachePath, raw, 0644)
+	}
+	return parsed, nil
+}
+
+// eventsEqual reports whether two ABIs declare the same event signatures.
+func eventsEqual(a, b *abi.ABI) bool {
+	if len(a.Events) != len(b.Events) {
+		return false
+	}
+	for name, event := range a.Events {
+		if other, ok := b.Events[name]; !ok || other.ID != event.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// handleSetContractInfo refreshes the ABIs when the Controller registers a new contract implementation.
+func (w *watcher) handleSetContractInfo(vLog types.Log) {
+	w.log.Println("Contract registration changed on the Controller, checking for ABI updates")
+	w.refreshABIs()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// actionRequest is the body of a request to the action API.
+type actionRequest struct {
+	Command string `json:"command"`
+	Arg     string `json:"arg"`
+}
+
+// actionHandler serves the action API: signed POST requests that run a bot command, e.g.
+// {"command": "mute", "arg": "6h"}, and answer with its reply. Requests are signed like the
+// generic webhook, and unsigned, stale or replayed requests are rejected.
+func actionHandler(secret string, watchers []*watcher, status *statusBoard) http.HandlerFunc {
+	verifier := newWebhookVerifier(secret)
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			rw.Header().Set("Allow", http.MethodPost)
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := verifier.verify(r.Header, body, time.Now()); err != nil {
+			log.Printf("Rejected action request from %s: %v", r.RemoteAddr, err)
+			http.Error(rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		var req actionRequest
+		if err := json.Unmarshal(body, &req); err != nil || req.Command == "" {
+			http.Error(rw, "expected a JSON object with a command", http.StatusBadRequest)
+			return
+		}
+		log.Printf("Running action %q %q from %s", req.Command, req.Arg, r.RemoteAddr)
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(map[string]string{"reply": runBotCommand(watchers, status, "the action API from "+r.RemoteAddr, req.Command, req.Arg)})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActionHandler(t *testing.T) {
+	defer muteAlerts(0)
+	handler := actionHandler("secret", nil, nil)
+	request := func(method, body, secret string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/actions", strings.NewReader(body))
+		timestamp := time.Now().Unix()
+		req.Header.Set("X-Timestamp", strconv.FormatInt(timestamp, 10))
+		req.Header.Set("X-Signature", "sha256="+signWebhook(secret, timestamp, []byte(body)))
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	if rec := request(http.MethodGet, "", "secret"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if rec := request(http.MethodPost, `{"command":"mute","arg":"1h"}`, "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if !mutedUntil().IsZero() {
+		t.Fatal("an unauthenticated request muted the alerts")
+	}
+	if rec := request(http.MethodPost, `{"arg":"1h"}`, "secret"); rec.Code != http.StatusBadRequest {
+		t.Errorf("missing command: status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec := request(http.MethodPost, `{"command":"mute","arg":"1h"}`, "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mute: status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var resp struct{ Reply string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(resp.Reply, "muted until") {
+		t.Errorf("reply = %q, want the mute confirmation", resp.Reply)
+	}
+	if mutedUntil().IsZero() {
+		t.Error("alerts are not muted")
+	}
+}
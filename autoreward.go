@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// autoRewardCheckInterval is how often the round progress is checked for --auto-reward.
+const autoRewardCheckInterval = 5 * time.Minute
+
+// maxAutoRewardAttempts limits the reward() transactions the watcher submits per orchestrator and round.
+const maxAutoRewardAttempts = 3
+
+// autoRewardState tracks the watcher's own reward() transactions for an orchestrator in a round.
+type autoRewardState struct {
+	attempts int
+	pending  bool
+}
+
+// checkAutoReward calls reward() from the watcher's account for orchestrators that still haven't
+// called it once --auto-reward percent of the round has elapsed. Only orchestrators whose own
+// address is the watcher's account are covered, as reward() pays out to the sender.
+func (w *watcher) checkAutoReward() {
+	if w.currentRound == 0 || w.silent {
+		return
+	}
+	var due []*orchestrator
+	for _, o := range w.orchestrators {
+		if !o.rewardCalled && !o.autoReward.pending && o.autoReward.attempts < maxAutoRewardAttempts && o.address == w.opts.txSigner.address {
+			due = append(due, o)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+	position, ok := w.roundPosition()
+	if !ok || position.progress() < w.opts.autoReward {
+		return
+	}
+	for _, o := range due {
+		w.autoReward(o)
+	}
+}
+
+// autoReward submits reward() for an orchestrator and alerts about the attempt.
+func (w *watcher) autoReward(o *orchestrator) {
+	round := w.currentRound
+	o.autoReward.attempts++
+	attempt := o.autoReward.attempts
+	tx, err := w.submitTx(w.net.Contracts.BondingManager, rewardABI, "reward", func(receipt *types.Receipt, err error) {
+		o.autoReward.pending = false
+		w.reportAutoReward(o, round, attempt, receipt, err)
+	})
+	if err != nil {
+		msg := fmt.Sprintf(tr("❌ Auto-reward attempt %d/%d for %s in round %d failed: %v"), attempt, maxAutoRewardAttempts, o.link(), round, err)
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "auto_reward_failed", msg, 0xFF0000)
+		return
+	}
+	o.autoReward.pending = true
+	hash := tx.Hex()
+	msg := fmt.Sprintf(
+		tr("🤖 Reward still missing for %s in round %d, so the watcher submitted reward() in transaction [%s](https://arbiscan.io/tx/%s) (attempt %d/%d)."),
+		o.link(), round, hash, hash, attempt, maxAutoRewardAttempts)
+	w.log.Println(msg)
+	w.orchestratorAlert(o, "auto_reward_submitted", msg, 0xFFA500)
+}
+
+// reportAutoReward alerts with the result of a reward() transaction submitted by the watcher.
+func (w *watcher) reportAutoReward(o *orchestrator, round uint64, attempt int, receipt *types.Receipt, err error) {
+	if err != nil {
+		msg := fmt.Sprintf(tr("❌ The auto-reward transaction for %s in round %d (attempt %d/%d) was not mined: %v"), o.link(), round, attempt, maxAutoRewardAttempts, err)
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "auto_reward_failed", msg, 0xFF0000)
+		return
+	}
+	hash := receipt.TxHash.Hex()
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		msg := fmt.Sprintf(
+			tr("❌ The auto-reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d reverted (attempt %d/%d)."),
+			hash, hash, o.link(), round, attempt, maxAutoRewardAttempts)
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "auto_reward_failed", msg, 0xFF0000)
+		return
+	}
+	msg := fmt.Sprintf(tr("✅ The watcher called reward for %s in round %d in transaction [%s](https://arbiscan.io/tx/%s)."), o.link(), round, hash, hash)
+	w.log.Println(msg)
+	w.orchestratorAlert(o, "auto_reward_called", msg, 0x00FF00)
+}
+
+// logAutoRewardCoverage logs the orchestrators --auto-reward can't call reward for, because
+// the watcher's account isn't the orchestrator itself.
+func (w *watcher) logAutoRewardCoverage() {
+	for _, o := range w.orchestrators {
+		if o.address != w.opts.txSigner.address {
+			w.log.Printf("auto-reward disabled for %s: the signing account %s is not the orchestrator, and reward() only pays out to its sender", o.address.Hex(), w.opts.txSigner.address.Hex())
+		}
+	}
+}
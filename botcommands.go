@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// alertMute silences all alert channels until a point in time. It is set through the chat bots.
+var alertMute struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// muteAlerts silences alerts for d, or lifts the mute if d is 0.
+func muteAlerts(d time.Duration) {
+	alertMute.mu.Lock()
+	defer alertMute.mu.Unlock()
+	alertMute.until = time.Now().Add(d)
+}
+
+// mutedUntil returns the end of the current mute, or the zero time if alerts are not muted.
+func mutedUntil() time.Time {
+	alertMute.mu.Lock()
+	defer alertMute.mu.Unlock()
+	if time.Now().After(alertMute.until) {
+		return time.Time{}
+	}
+	return alertMute.until
+}
+
+// liveStatus returns the current state of a watcher, read on its goroutine. A watcher that is
+// busy reconnecting doesn't answer in time and is reported with its last published status.
+func liveStatus(w *watcher, status *statusBoard) watcherStatus {
+	reply := make(chan watcherStatus, 1)
+	select {
+	case w.control <- func() { reply <- w.snapshot() }:
+		return <-reply
+	case <-time.After(2 * time.Second):
+		status.mu.Lock()
+		defer status.mu.Unlock()
+		return status.statuses[w.net.Name]
+	}
+}
+
+// runBotCommand executes a chat bot command and returns the markdown reply. Commands may use
+// "_" instead of "-", as Telegram commands can't contain dashes. user describes who sent the
+// command, for the log of who muted alerts.
+func runBotCommand(watchers []*watcher, status *statusBoard, user, command, arg string) string {
+	var b strings.Builder
+	switch strings.ReplaceAll(command, "_", "-") {
+	case "reward-status", "status":
+		for _, w := range watchers {
+			s := liveStatus(w, status)
+			fmt.Fprintf(&b, "**%s** round %d", s.Network, s.CurrentRound)
+			if !s.RoundStart.IsZero() {
+				fmt.Fprintf(&b, " (started %s ago)", formatDuration(time.Since(s.RoundStart).Round(time.Minute)))
+			}
+			b.WriteString(":\n")
+			for _, o := range s.Orchestrators {
+				if o.RewardCalled {
+					fmt.Fprintf(&b, "✅ %s called reward\n", strings.ToLower(o.Address))
+				} else {
+					fmt.Fprintf(&b, "⏳ %s has not called reward yet\n", strings.ToLower(o.Address))
+				}
+			}
+		}
+	case "round":
+		for _, w := range watchers {
+			s := liveStatus(w, status)
+			fmt.Fprintf(&b, "**%s** round %d", s.Network, s.CurrentRound)
+			if !s.RoundStart.IsZero() {
+				fmt.Fprintf(&b, ", started %s (%s ago)", formatDate(s.RoundStart), formatDuration(time.Since(s.RoundStart).Round(time.Minute)))
+			}
+			fmt.Fprintf(&b, ", last processed block %d", s.LastProcessedBlock)
+			if s.ConnectedRPC == "" {
+				b.WriteString(", ⚠️ not connected to an RPC")
+			}
+			b.WriteString(".\n")
+		}
+	case "lastreward", "last-reward":
+		for _, w := range watchers {
+			s := liveStatus(w, status)
+			fmt.Fprintf(&b, "**%s**:\n", s.Network)
+			for _, o := range s.Orchestrators {
+				address := strings.ToLower(o.Address)
+				switch {
+				case o.LastRewardTx != "":
+					fmt.Fprintf(&b, "%s called reward in round %d, %s ago ([tx](https://arbiscan.io/tx/%s))\n",
+						address, o.LastRewardRound, formatDuration(time.Since(o.LastRewardTime).Round(time.Minute)), o.LastRewardTx)
+				case o.LastRewardRound != 0:
+					fmt.Fprintf(&b, "%s last called reward in round %d\n", address, o.LastRewardRound)
+				default:
+					fmt.Fprintf(&b, "%s has no reward call on record\n", address)
+				}
+			}
+		}
+	case "mute":
+		d, err := time.ParseDuration(strings.TrimSpace(arg))
+		if err != nil || d <= 0 {
+			return tr("Usage: mute <duration>, e.g. mute 6h")
+		}
+		until := time.Now().Add(d)
+		muteAlerts(d)
+		log.Printf("Alerts muted until %s by %s", until.Format(time.RFC3339), user)
+		return fmt.Sprintf(tr("🔇 Alerts muted until %s."), formatDate(until))
+	case "unmute":
+		muteAlerts(0)
+		log.Printf("Alerts unmuted by %s", user)
+		return tr("🔔 Alerts unmuted.")
+	default:
+		return tr("Unknown command. Available commands: reward-status, round, lastreward, mute <duration>, unmute.")
+	}
+	if until := mutedUntil(); !until.IsZero() {
+		fmt.Fprintf(&b, tr("🔇 Alerts are muted until %s."), formatDate(until))
+	}
+	return strings.TrimSpace(b.String())
+}
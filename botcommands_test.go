@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunBotCommandReplies(t *testing.T) {
+	defer setLanguage("en")
+	defer muteAlerts(0)
+	tests := []struct {
+		lang, command, arg string
+		want               string
+	}{
+		{"en", "mute", "", "Usage: mute <duration>"},
+		{"de", "mute", "soon", "Verwendung: mute <Dauer>"},
+		{"en", "help", "", "Unknown command."},
+		{"es", "help", "", "Comando desconocido."},
+		{"en", "mute", "6h", "🔇 Alerts muted until"},
+		{"de", "reward-status", "", "🔇 Warnungen sind stummgeschaltet bis"},
+		{"zh", "unmute", "", "🔔 告警已取消静音。"},
+	}
+	for _, tt := range tests {
+		if err := setLanguage(tt.lang); err != nil {
+			t.Fatal(err)
+		}
+		if got := runBotCommand(nil, nil, "test", tt.command, tt.arg); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: %s %q = %q, want it to contain %q", tt.lang, tt.command, tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestDiscordMuteCommandsNeedPermission(t *testing.T) {
+	for _, c := range discordCommands {
+		restricted := c.DefaultMemberPermissions != nil && *c.DefaultMemberPermissions != 0
+		if want := c.Name == "mute" || c.Name == "unmute"; restricted != want {
+			t.Errorf("/%s: restricted = %v, want %v", c.Name, restricted, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceGapState tracks how long the reward caller has had transactions stuck in the mempool.
+type nonceGapState struct {
+	since   time.Time
+	alerted bool
+}
+
+// checkCallers checks the reward caller of every orchestrator, once per distinct account.
+func (w *watcher) checkCallers() {
+	checked := make(map[common.Address]bool)
+	for _, o := range w.orchestrators {
+		if checked[o.rewardCaller] {
+			continue
+		}
+		checked[o.rewardCaller] = true
+		if w.opts.stuckTxTimeout > 0 {
+			w.checkCallerNonce(o)
+		}
+		if w.opts.minCallerBalance > 0 {
+			w.checkCallerBalance(o)
+		}
+	}
+}
+
+// checkCallerNonce alerts when the reward caller's pending nonce stays ahead of its confirmed
+// nonce for longer than the stuck transaction timeout, indicating stuck transactions.
+func (w *watcher) checkCallerNonce(o *orchestrator) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	caller := o.rewardCaller
+	pending, err := w.client.PendingNonceAt(ctx, caller)
+	if err != nil {
+		w.log.Printf("failed to fetch pending nonce of reward caller %s: %v", caller.Hex(), err)
+		return
+	}
+	confirmed, err := w.client.NonceAt(ctx, caller, nil)
+	if err != nil {
+		w.log.Printf("failed to fetch nonce of reward caller %s: %v", caller.Hex(), err)
+		return
+	}
+	if pending <= confirmed {
+		if o.nonceGap.alerted {
+			resolvedMsg := fmt.Sprintf(tr("✅ Pending transactions of reward caller %s have been mined."), caller.Hex())
+			w.log.Println(resolvedMsg)
+			w.orchestratorAlert(o, "caller_tx_mined", resolvedMsg, 0x00FF00)
+		}
+		o.nonceGap = nonceGapState{}
+		return
+	}
+	if o.nonceGap.since.IsZero() {
+		o.nonceGap.since = time.Now()
+	}
+	if !o.nonceGap.alerted && time.Since(o.nonceGap.since) >= w.opts.stuckTxTimeout {
+		stuckMsg := fmt.Sprintf(
+			tr("⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %d transaction(s) pending for over %s (nonce %d). They may be stuck and block reward calls."),
+			caller.Hex(), caller.Hex(), pending-confirmed, formatDuration(w.opts.stuckTxTimeout), confirmed)
+		w.log.Println(stuckMsg)
+		w.orchestratorAlert(o, "caller_tx_stuck", stuckMsg, 0xFFA500)
+		o.nonceGap.alerted = true
+	}
+}
+
+// checkCallerBalance alerts when the reward caller's ETH balance drops below the minimum balance,
+// as an empty gas wallet can't pay for reward calls, and when it is topped up again.
+func (w *watcher) checkCallerBalance(o *orchestrator) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	caller := o.rewardCaller
+	balance, err := w.client.BalanceAt(ctx, caller, nil)
+	if err != nil {
+		w.log.Printf("failed to fetch balance of reward caller %s: %v", caller.Hex(), err)
+		return
+	}
+	min, _ := new(big.Float).Mul(big.NewFloat(w.opts.minCallerBalance), big.NewFloat(1e18)).Int(nil)
+	low := balance.Cmp(min) < 0
+	if low == o.lowBalance {
+		return
+	}
+	o.lowBalance = low
+	msg := fmt.Sprintf(
+		tr("✅ Reward caller [%s](https://arbiscan.io/address/%s) was topped up to %s ETH."),
+		caller.Hex(), caller.Hex(), formatUnits(balance, 18, 6))
+	color, kind := 0x00FF00, "caller_balance_restored"
+	if low {
+		msg = fmt.Sprintf(
+			tr("⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %s ETH left, below the minimum of %g ETH. Top it up so it can pay for reward calls."),
+			caller.Hex(), caller.Hex(), formatUnits(balance, 18, 6), w.opts.minCallerBalance)
+		color, kind = 0xFFA500, "caller_balance_low"
+	}
+	w.log.Println(msg)
+	w.orchestratorAlert(o, kind, msg, color)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// eventQueries are the filters of the orchestrator events, shared by the live subscriptions
+// and the catch-up.
+type eventQueries struct {
+	reward       ethereum.FilterQuery
+	newRound     ethereum.FilterQuery
+	ticket       ethereum.FilterQuery
+	deactivation ethereum.FilterQuery
+}
+
+// eventQueries returns the filters of the events the watcher processes.
+func (w *watcher) eventQueries() eventQueries {
+	orchestrators := w.orchestratorTopics()
+	return eventQueries{
+		reward: ethereum.FilterQuery{
+			Addresses: []common.Address{w.net.Contracts.BondingManager},
+			Topics:    [][]common.Hash{{w.abis.BondingManager.Events["Reward"].ID}, orchestrators},
+		},
+		newRound: ethereum.FilterQuery{
+			Addresses: []common.Address{w.net.Contracts.RoundsManager},
+			Topics:    [][]common.Hash{{w.abis.RoundsManager.Events["NewRound"].ID}},
+		},
+		ticket: ethereum.FilterQuery{
+			Addresses: []common.Address{w.net.Contracts.TicketBroker},
+			Topics:    [][]common.Hash{{w.abis.TicketBroker.Events["WinningTicketRedeemed"].ID}, nil, orchestrators},
+		},
+		deactivation: ethereum.FilterQuery{
+			Addresses: []common.Address{w.net.Contracts.BondingManager},
+			Topics:    [][]common.Hash{{w.abis.BondingManager.Events["TranscoderDeactivated"].ID}, orchestrators},
+		},
+	}
+}
+
+// handleLog runs an event handler for a live event, skipping events the catch-up already replayed.
+func (w *watcher) handleLog(vLog types.Log, handle func(types.Log)) {
+	if vLog.BlockNumber <= w.caughtUpTo && !vLog.Removed {
+		return
+	}
+	w.lastEventTime = time.Now()
+	w.headLag.processed(vLog.BlockNumber)
+	w.queueLog(vLog, handle)
+}
+
+// filterLogs runs a log query over a block range in chunks of --log-chunk-size blocks, as most
+// RPC providers limit the range of a single query.
+func (w *watcher) filterLogs(query ethereum.FilterQuery, from, to uint64) ([]types.Log, error) {
+	var logs []types.Log
+	for start := from; start <= to; {
+		end := chunkEnd(start, to, w.opts.logChunkSize)
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		chunk, err := w.client.FilterLogs(ctx, query)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query logs of blocks %d-%d: %v", start, end, err)
+		}
+		logs = append(logs, chunk...)
+		start = end + 1
+	}
+	return logs, nil
+}
+
+// chunkEnd returns the last block of the query range of at most size blocks starting at start,
+// cut short at to.
+func chunkEnd(start, to, size uint64) uint64 {
+	if end := start + size - 1; end < to {
+		return end
+	}
+	return to
+}
+
+// findRoundStart searches backwards from head for the latest NewRound event, at most
+// --catch-up-blocks blocks deep.
+func (w *watcher) findRoundStart(query ethereum.FilterQuery, head uint64) (uint64, bool, error) {
+	var searched uint64
+	for end := head; searched < w.opts.catchUpBlocks; {
+		size := w.opts.logChunkSize
+		if rest := w.opts.catchUpBlocks - searched; size > rest {
+			size = rest
+		}
+		start := uint64(0)
+		if end+1 > size {
+			start = end + 1 - size
+		}
+		logs, err := w.filterLogs(query, start, end)
+		if err != nil {
+			return 0, false, err
+		}
+		if len(logs) > 0 {
+			return logs[len(logs)-1].BlockNumber, true, nil
+		}
+		if start == 0 {
+			break
+		}
+		searched += end - start + 1
+		end = start - 1
+	}
+	return 0, false, nil
+}
+
+// catchUp replays the events missed while the watcher was not connected, so a restart mid-round
+// knows the current round and whether reward was already called. Without a known last processed
+// block (first start, or a gap longer than --catch-up-blocks) it replays from the start of the
+// current round without sending alerts; otherwise it replays from the last processed block and
+// alerts as usual.
+func (w *watcher) catchUp() {
+	if w.opts.catchUpBlocks == 0 || w.opts.logChunkSize == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	head, err := w.client.BlockNumber(ctx)
+	cancel()
+	if err != nil {
+		w.log.Printf("catch-up skipped, failed to fetch block number: %v", err)
+		return
+	}
+	queries := w.eventQueries()
+	from := w.headLag.lastProcessed + 1
+	silent := !w.sentInitialMonitoringAlert
+	if w.headLag.lastProcessed == 0 || head-w.headLag.lastProcessed > w.opts.catchUpBlocks {
+		silent = true
+		start, found, err := w.findRoundStart(queries.newRound, head)
+		if err != nil {
+			w.log.Printf("catch-up skipped: %v", err)
+			return
+		}
+		if !found {
+			w.log.Printf("catch-up skipped, no NewRound event in the last %d blocks", w.opts.catchUpBlocks)
+			return
+		}
+		from = start
+	}
+	if from > head {
+		w.caughtUpTo = head
+		return
+	}
+
+	type replay struct {
+		log    types.Log
+		handle func(types.Log)
+	}
+	var events []replay
+	for _, q := range []struct {
+		query  ethereum.FilterQuery
+		handle func(types.Log)
+	}{
+		{queries.newRound, w.handleNewRound},
+		{queries.reward, w.handleReward},
+		{queries.ticket, w.handleWinningTicket},
+		{queries.deactivation, w.handleTranscoderDeactivated},
+	} {
+		logs, err := w.filterLogs(q.query, from, head)
+		if err != nil {
+			w.log.Printf("catch-up skipped: %v", err)
+			return
+		}
+		for _, l := range logs {
+			events = append(events, replay{l, q.handle})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].log.BlockNumber != events[j].log.BlockNumber {
+			return events[i].log.BlockNumber < events[j].log.BlockNumber
+		}
+		return events[i].log.Index < events[j].log.Index
+	})
+
+	w.catchingUp, w.silent = true, silent
+	for _, e := range events {
+		e.handle(e.log)
+	}
+	w.catchingUp, w.silent = false, false
+	w.caughtUpTo = head
+	w.headLag.processed(head)
+	w.log.Printf("Caught up on blocks %d-%d: %d event(s) replayed, current round %d", from, head, len(events), w.currentRound)
+}
+
+// eventTime returns when an event happened: now for live events, the block time for replayed ones.
+func (w *watcher) eventTime(vLog types.Log) time.Time {
+	if !w.catchingUp {
+		return time.Now()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(vLog.BlockNumber))
+	if err != nil {
+		return time.Now()
+	}
+	return time.Unix(int64(header.Time), 0)
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+func TestChunkEnd(t *testing.T) {
+	tests := []struct {
+		name            string
+		start, to, size uint64
+		want            uint64
+	}{
+		{"full chunk", 100, 1000, 50, 149},
+		{"cut short at to", 980, 1000, 50, 1000},
+		{"chunk ends at to", 951, 1000, 50, 1000},
+		{"single block range", 1000, 1000, 50, 1000},
+		{"single block chunks", 7, 10, 1, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chunkEnd(tt.start, tt.to, tt.size); got != tt.want {
+				t.Errorf("chunkEnd(%d, %d, %d) = %d, want %d", tt.start, tt.to, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkEndCoversRange(t *testing.T) {
+	tests := []struct {
+		name                 string
+		from, to, size       uint64
+		wantChunks, wantLast uint64
+	}{
+		{"exact multiple", 1, 100, 10, 10, 100},
+		{"partial last chunk", 1, 105, 10, 11, 105},
+		{"smaller than one chunk", 50, 55, 10, 1, 55},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks, last uint64
+			for next := tt.from; next <= tt.to; {
+				end := chunkEnd(next, tt.to, tt.size)
+				if end < next || end-next+1 > tt.size {
+					t.Fatalf("chunk %d-%d exceeds %d blocks", next, end, tt.size)
+				}
+				chunks, last, next = chunks+1, end, end+1
+			}
+			if chunks != tt.wantChunks || last != tt.wantLast {
+				t.Errorf("got %d chunks ending at %d, want %d ending at %d", chunks, last, tt.wantChunks, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the layout of the configuration file.
+type fileConfig struct {
+	// Options sets command line flags by name (e.g. "delay": "2h"). Flags given on the command
+	// line take precedence.
+	Options  map[string]interface{} `json:"options"`
+	Networks []networkConfig        `json:"networks"`
+}
+
+// networkConfig describes a network to watch. Alert channels left unset fall back to the
+// environment, while alert settings given as flags take precedence over the values in the file.
+type networkConfig struct {
+	Name         string `json:"name"`
+	ChainID      uint64 `json:"chainId"`
+	Orchestrator string `json:"orchestrator"`
+	RewardCaller string `json:"rewardCaller"`
+	// Orchestrators and RewardCallers watch several orchestrators on the network.
+	Orchestrators []string `json:"orchestrators"`
+	RewardCallers []string `json:"rewardCallers"`
+	Delegators    []string `json:"delegators"`
+	RPCs          []string `json:"rpcs"`
+	RPCListURL    string   `json:"rpcListUrl"`
+	ReferenceRPC  string   `json:"referenceRpc"`
+	// RPCAuth sets headers or basic auth per RPC endpoint URL.
+	RPCAuth       map[string]rpcAuthConfig `json:"rpcAuth"`
+	NodeStatusURL string                   `json:"nodeStatusUrl"`
+	Contracts     struct {
+		BondingManager  string `json:"bondingManager"`
+		RoundsManager   string `json:"roundsManager"`
+		TicketBroker    string `json:"ticketBroker"`
+		ServiceRegistry string `json:"serviceRegistry"`
+		Controller      string `json:"controller"`
+	} `json:"contracts"`
+	TelegramBotToken     string              `json:"telegramBotToken"`
+	TelegramChatID       string              `json:"telegramChatId"`
+	TelegramTopics       map[string]int      `json:"telegramTopics"`
+	DiscordWebhookURL    string              `json:"discordWebhookUrl"`
+	DiscordMentions      *discordMentions    `json:"discordMentions"`
+	SlackWebhookURL      string              `json:"slackWebhookUrl"`
+	TeamsWebhookURL      string              `json:"teamsWebhookUrl"`
+	GoogleChatWebhookURL string              `json:"googleChatWebhookUrl"`
+	RocketChatWebhookURL string              `json:"rocketChatWebhookUrl"`
+	Mattermost           *mattermostConfig   `json:"mattermost"`
+	Matrix               *matrixConfig       `json:"matrix"`
+	Zulip                *zulipConfig        `json:"zulip"`
+	Signal               *signalConfig       `json:"signal"`
+	XMPP                 *xmppConfig         `json:"xmpp"`
+	IRC                  *ircConfig          `json:"irc"`
+	MQTT                 *mqttConfig         `json:"mqtt"`
+	Kafka                *kafkaConfig        `json:"kafka"`
+	NATS                 *natsConfig         `json:"nats"`
+	Syslog               *syslogConfig       `json:"syslog"`
+	PagerDuty            *pagerDutyConfig    `json:"pagerduty"`
+	Opsgenie             *opsgenieConfig     `json:"opsgenie"`
+	Pushover             *pushoverConfig     `json:"pushover"`
+	Ntfy                 *ntfyConfig         `json:"ntfy"`
+	Gotify               *gotifyConfig       `json:"gotify"`
+	Twilio               *twilioConfig       `json:"twilio"`
+	Email                *EmailConfig        `json:"email"`
+	Branding             *branding           `json:"branding"`
+	Webhook              *webhookConfig      `json:"webhook"`
+	AlertCommand         string              `json:"alertCommand"`
+	NotifyURLs           []string            `json:"notifyUrls"`
+	FallbackChannels     []string            `json:"fallbackChannels"`
+	Routes               map[string][]string `json:"routes"`
+	MinSeverity          map[string]string   `json:"minSeverity"`
+	QuietHours           *quietHours         `json:"quietHours"`
+	Escalation           string              `json:"escalation"`
+}
+
+// loadConfig reads the configuration file at path. YAML (.yaml, .yml) and TOML (.toml) files
+// are converted to JSON first, so all formats share the same field names.
+func loadConfig(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+	var raw interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		var table map[string]interface{}
+		err = toml.Unmarshal(data, &table)
+		raw = table
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	if raw != nil {
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	}
+	var cfg fileConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep large option values such as block counts exact.
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+// networks converts the configured networks, using defaults for unset alert channels.
+func (c *fileConfig) networks(defaults notifier) ([]network, error) {
+	var out []network
+	for i, nc := range c.Networks {
+		if nc.Name == "" {
+			nc.Name = fmt.Sprintf("network %d", i+1)
+		}
+		if nc.ChainID == 0 {
+			nc.ChainID = arbitrumOneChainID
+		}
+		addresses, callers := nc.Orchestrators, nc.RewardCallers
+		if nc.Orchestrator != "" {
+			addresses = append([]string{nc.Orchestrator}, addresses...)
+		}
+		if nc.RewardCaller != "" {
+			callers = append([]string{nc.RewardCaller}, callers...)
+		}
+		orchestrators, err := parseOrchestrators(addresses, callers)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		delegators, err := parseAddresses(nc.Delegators)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		if len(nc.RPCs) == 0 && nc.RPCListURL == "" {
+			return nil, fmt.Errorf("%s: no RPC endpoints configured", nc.Name)
+		}
+		for url, auth := range nc.RPCAuth {
+			if err := auth.validate(url); err != nil {
+				return nil, fmt.Errorf("%s: %v", nc.Name, err)
+			}
+		}
+		contracts := arbitrumOneContracts
+		for _, addr := range []struct {
+			raw    string
+			target *common.Address
+		}{
+			{nc.Contracts.BondingManager, &contracts.BondingManager},
+			{nc.Contracts.RoundsManager, &contracts.RoundsManager},
+			{nc.Contracts.TicketBroker, &contracts.TicketBroker},
+			{nc.Contracts.ServiceRegistry, &contracts.ServiceRegistry},
+			{nc.Contracts.Controller, &contracts.Controller},
+		} {
+			if addr.raw == "" {
+				continue
+			}
+			if !common.IsHexAddress(addr.raw) {
+				return nil, fmt.Errorf("%s: invalid contract address %q", nc.Name, addr.raw)
+			}
+			*addr.target = common.HexToAddress(addr.raw)
+		}
+		n := defaults
+		n.TelegramBotToken = envValue(nc.TelegramBotToken, n.TelegramBotToken)
+		n.TelegramChatID = envValue(nc.TelegramChatID, n.TelegramChatID)
+		n.TelegramTopics = envValue(nc.TelegramTopics, n.TelegramTopics)
+		n.DiscordWebhook = envValue(nc.DiscordWebhookURL, n.DiscordWebhook)
+		if nc.DiscordMentions != nil {
+			n.DiscordMentions = envValue(*nc.DiscordMentions, n.DiscordMentions)
+		}
+		n.SlackWebhook = envValue(nc.SlackWebhookURL, n.SlackWebhook)
+		n.TeamsWebhook = envValue(nc.TeamsWebhookURL, n.TeamsWebhook)
+		n.GoogleChatWebhook = envValue(nc.GoogleChatWebhookURL, n.GoogleChatWebhook)
+		n.RocketChatWebhook = envValue(nc.RocketChatWebhookURL, n.RocketChatWebhook)
+		if nc.Mattermost != nil {
+			n.Mattermost = envValue(*nc.Mattermost, n.Mattermost)
+		}
+		if nc.Matrix != nil {
+			n.Matrix = envValue(*nc.Matrix, n.Matrix)
+		}
+		if nc.Zulip != nil {
+			n.Zulip = envValue(*nc.Zulip, n.Zulip)
+		}
+		if nc.Signal != nil {
+			n.Signal = envValue(*nc.Signal, n.Signal)
+		}
+		if nc.XMPP != nil {
+			n.XMPP = envValue(*nc.XMPP, n.XMPP)
+		}
+		if nc.IRC != nil {
+			n.IRC = envValue(*nc.IRC, n.IRC)
+		}
+		if nc.MQTT != nil {
+			n.MQTT = envValue(*nc.MQTT, n.MQTT)
+		}
+		if nc.Kafka != nil {
+			n.Kafka = envValue(*nc.Kafka, n.Kafka)
+		}
+		if nc.NATS != nil {
+			n.NATS = envValue(*nc.NATS, n.NATS)
+		}
+		if nc.Syslog != nil {
+			n.Syslog = envValue(*nc.Syslog, n.Syslog)
+		}
+		if nc.PagerDuty != nil {
+			n.PagerDuty = envValue(*nc.PagerDuty, n.PagerDuty)
+		}
+		if nc.Opsgenie != nil {
+			n.Opsgenie = envValue(*nc.Opsgenie, n.Opsgenie)
+		}
+		if nc.Pushover != nil {
+			n.Pushover = envValue(*nc.Pushover, n.Pushover)
+		}
+		if nc.Ntfy != nil {
+			n.Ntfy = envValue(*nc.Ntfy, n.Ntfy)
+		}
+		if nc.Gotify != nil {
+			n.Gotify = envValue(*nc.Gotify, n.Gotify)
+		}
+		if nc.Twilio != nil {
+			n.Twilio = envValue(*nc.Twilio, n.Twilio)
+		}
+		n.Command = envValue(nc.AlertCommand, n.Command)
+		if len(nc.NotifyURLs) > 0 {
+			targets, err := parseNotifyURLs(nc.NotifyURLs)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", nc.Name, err)
+			}
+			n.URLs = envValue(targets, n.URLs)
+		}
+		if nc.Webhook != nil {
+			n.Webhook = envValue(*nc.Webhook, n.Webhook)
+		}
+		if nc.Branding != nil {
+			n.Branding = envValue(*nc.Branding, n.Branding)
+		}
+		if nc.Email != nil {
+			n.Email = envValue(*nc.Email, n.Email)
+			if err := n.Email.validate(); err != nil {
+				return nil, fmt.Errorf("%s: %v", nc.Name, err)
+			}
+		}
+		n.Fallback = fileValue(n.Fallback, nc.FallbackChannels)
+		if err := validateChannels(n.Fallback); err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		n.Routes = expandRouteTypes(fileValue(n.Routes, nc.Routes))
+		if err := validateRoutes(n.Routes); err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		n.MinSeverity = fileValue(n.MinSeverity, nc.MinSeverity)
+		if err := validateMinSeverities(n.MinSeverity); err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		if nc.Escalation != "" {
+			stages, err := parseEscalation(nc.Escalation)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", nc.Name, err)
+			}
+			n.Escalation = fileValue(n.Escalation, stages)
+		}
+		if nc.QuietHours != nil {
+			n.Quiet = fileValue(n.Quiet, *nc.QuietHours)
+		}
+		if err := n.Quiet.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", nc.Name, err)
+		}
+		if !n.configured() {
+			return nil, fmt.Errorf("%s: no alert channel configured", nc.Name)
+		}
+		n.Label = nc.Name
+		n.threads = newAlertThreads()
+		n.quietQueue = newQuietQueue()
+		n.emailDigest = newEmailDigest()
+		n.limiter = newAlertLimiter()
+		out = append(out, network{
+			Name:          nc.Name,
+			ChainID:       nc.ChainID,
+			Orchestrators: orchestrators,
+			Delegators:    delegators,
+			NodeStatusURL: nc.NodeStatusURL,
+			RPCListURL:    nc.RPCListURL,
+			ReferenceRPC:  nc.ReferenceRPC,
+			RPCs:          nc.RPCs,
+			RPCAuth:       nc.RPCAuth,
+			Contracts:     contracts,
+			Notifier:      &n,
+		})
+	}
+	return out, nil
+}
+
+// fileValue returns the value of the config file unless a command line flag already set one, as
+// flags take precedence over the file.
+func fileValue[T any](current, file T) T {
+	if unset(reflect.ValueOf(current)) {
+		return file
+	}
+	return current
+}
+
+// envValue returns the network's value from the config file, falling back to the environment
+// when the file leaves it unset, so a global environment variable never overrides the channel
+// of one network.
+func envValue[T any](file, env T) T {
+	if unset(reflect.ValueOf(file)) {
+		return env
+	}
+	return file
+}
+
+// unset reports whether v holds no setting: a zero value, an empty slice, or a map or struct
+// whose exported values are all unset.
+func unset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && !unset(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if !unset(iter.Value()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// applyOptions sets the flags configured in the file that were not given on the command line.
+func (c *fileConfig) applyOptions(fs *flag.FlagSet) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for name, value := range c.Options {
+		if name == "config" || fs.Lookup(name) == nil {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		if given[name] {
+			continue
+		}
+		raw := fmt.Sprint(value)
+		if list, ok := value.([]interface{}); ok {
+			parts := make([]string, 0, len(list))
+			for _, item := range list {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			raw = strings.Join(parts, ",")
+		}
+		if err := fs.Set(name, raw); err != nil {
+			return fmt.Errorf("invalid option %q: %v", name, err)
+		}
+	}
+	return nil
+}
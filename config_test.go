@@ -0,0 +1,160 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNetworksPrecedence(t *testing.T) {
+	file := networkConfig{
+		Name:             "arbitrum",
+		Orchestrator:     "0x0000000000000000000000000000000000000001",
+		RPCs:             []string{"https://rpc.example.com"},
+		TelegramBotToken: "file-token",
+		TelegramChatID:   "file-chat",
+		Mattermost:       &mattermostConfig{WebhookURL: "https://mm.example.com/hooks/file"},
+		FallbackChannels: []string{"email"},
+		Routes:           map[string][]string{"reward-success": nil},
+		Escalation:       "0s=telegram;2h=pagerduty",
+		QuietHours:       &quietHours{Window: "23:00-07:00"},
+	}
+	tests := []struct {
+		name     string
+		defaults notifier
+		check    func(t *testing.T, n *notifier)
+	}{
+		{
+			name: "file fills unset values",
+			check: func(t *testing.T, n *notifier) {
+				if n.TelegramBotToken != "file-token" || n.TelegramChatID != "file-chat" {
+					t.Errorf("telegram = %q/%q, want the file values", n.TelegramBotToken, n.TelegramChatID)
+				}
+				if n.Mattermost.WebhookURL != "https://mm.example.com/hooks/file" {
+					t.Errorf("mattermost webhook = %q, want the file value", n.Mattermost.WebhookURL)
+				}
+				if !reflect.DeepEqual(n.Fallback, []string{"email"}) {
+					t.Errorf("fallback = %v, want the file value", n.Fallback)
+				}
+				if want := map[string][]string{"reward": nil}; !reflect.DeepEqual(n.Routes, want) {
+					t.Errorf("routes = %v, want %v", n.Routes, want)
+				}
+				if len(n.Escalation) != 2 || n.Escalation[1].After != 2*time.Hour {
+					t.Errorf("escalation = %v, want the file stages", n.Escalation)
+				}
+				if n.Quiet.Window != "23:00-07:00" {
+					t.Errorf("quiet hours = %q, want the file window", n.Quiet.Window)
+				}
+			},
+		},
+		{
+			name: "file wins over the environment, flags win over the file",
+			defaults: notifier{
+				TelegramBotToken: "env-token",
+				TelegramChatID:   "env-chat",
+				Mattermost:       mattermostConfig{WebhookURL: "https://mm.example.com/hooks/env"},
+				Fallback:         []string{"sms"},
+				Routes:           map[string][]string{"new_round": {"discord"}},
+				Escalation:       []escalationStage{{After: time.Hour, Channels: []string{"sms"}}},
+				Quiet:            quietHours{Window: "22:00-06:00"},
+			},
+			check: func(t *testing.T, n *notifier) {
+				if n.TelegramBotToken != "file-token" || n.TelegramChatID != "file-chat" {
+					t.Errorf("telegram = %q/%q, want the file values", n.TelegramBotToken, n.TelegramChatID)
+				}
+				if n.Mattermost.WebhookURL != "https://mm.example.com/hooks/file" {
+					t.Errorf("mattermost webhook = %q, want the file value", n.Mattermost.WebhookURL)
+				}
+				if !reflect.DeepEqual(n.Fallback, []string{"sms"}) {
+					t.Errorf("fallback = %v, want the flag value", n.Fallback)
+				}
+				if want := map[string][]string{"new_round": {"discord"}}; !reflect.DeepEqual(n.Routes, want) {
+					t.Errorf("routes = %v, want %v", n.Routes, want)
+				}
+				if len(n.Escalation) != 1 || n.Escalation[0].After != time.Hour {
+					t.Errorf("escalation = %v, want the flag stages", n.Escalation)
+				}
+				if n.Quiet.Window != "22:00-06:00" {
+					t.Errorf("quiet hours = %q, want the flag window", n.Quiet.Window)
+				}
+			},
+		},
+		{
+			name: "empty structs count as unset",
+			defaults: notifier{
+				Mattermost: mattermostConfig{Channels: map[string]string{"critical": ""}},
+				Quiet:      quietHours{Channels: []string{}},
+			},
+			check: func(t *testing.T, n *notifier) {
+				if n.Mattermost.WebhookURL != "https://mm.example.com/hooks/file" {
+					t.Errorf("mattermost webhook = %q, want the file value", n.Mattermost.WebhookURL)
+				}
+				if n.Quiet.Window != "23:00-07:00" {
+					t.Errorf("quiet hours = %q, want the file window", n.Quiet.Window)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := fileConfig{Networks: []networkConfig{file}}
+			networks, err := cfg.networks(tt.defaults)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(networks) != 1 {
+				t.Fatalf("got %d networks, want 1", len(networks))
+			}
+			tt.check(t, networks[0].Notifier)
+		})
+	}
+}
+
+func TestNetworksEnvironmentFallback(t *testing.T) {
+	cfg := fileConfig{Networks: []networkConfig{
+		{
+			Name:              "arbitrum",
+			Orchestrator:      "0x0000000000000000000000000000000000000001",
+			RPCs:              []string{"https://rpc.example.com"},
+			DiscordWebhookURL: "https://discord.example.com/arbitrum",
+			Mattermost:        &mattermostConfig{WebhookURL: "https://mm.example.com/hooks/arbitrum"},
+		},
+		{
+			Name:         "testnet",
+			ChainID:      421614,
+			Orchestrator: "0x0000000000000000000000000000000000000002",
+			RPCs:         []string{"https://testnet.example.com"},
+		},
+	}}
+	defaults := notifier{
+		DiscordWebhook: "https://discord.example.com/env",
+		Mattermost:     mattermostConfig{WebhookURL: "https://mm.example.com/hooks/env"},
+	}
+	networks, err := cfg.networks(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networks) != 2 {
+		t.Fatalf("got %d networks, want 2", len(networks))
+	}
+	tests := []struct {
+		network    string
+		discord    string
+		mattermost string
+	}{
+		{"arbitrum", "https://discord.example.com/arbitrum", "https://mm.example.com/hooks/arbitrum"},
+		{"testnet", "https://discord.example.com/env", "https://mm.example.com/hooks/env"},
+	}
+	for i, tt := range tests {
+		n := networks[i].Notifier
+		if networks[i].Name != tt.network {
+			t.Fatalf("network %d = %q, want %q", i, networks[i].Name, tt.network)
+		}
+		if n.DiscordWebhook != tt.discord {
+			t.Errorf("%s: discord webhook = %q, want %q", tt.network, n.DiscordWebhook, tt.discord)
+		}
+		if n.Mattermost.WebhookURL != tt.mattermost {
+			t.Errorf("%s: mattermost webhook = %q, want %q", tt.network, n.Mattermost.WebhookURL, tt.mattermost)
+		}
+	}
+}
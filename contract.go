@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// callContract calls a read-only contract method at the latest block and returns its unpacked outputs.
+func (w *watcher) callContract(contractABI abi.ABI, address common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %v", method, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := w.client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s call failed: %v", method, err)
+	}
+	values, err := contractABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %v", method, err)
+	}
+	return values, nil
+}
+
+// outputBigInt returns the named uint256 output of a contract call.
+func outputBigInt(contractABI abi.ABI, method string, values []interface{}, name string) (*big.Int, error) {
+	for i, output := range contractABI.Methods[method].Outputs {
+		if output.Name != name || i >= len(values) {
+			continue
+		}
+		if v, ok := values[i].(*big.Int); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no uint256 output %q", method, name)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxFutureRound is the deactivation round of orchestrators that are not scheduled to leave the active set.
+var maxFutureRound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// handleTranscoderDeactivated processes a TranscoderDeactivated event of a watched orchestrator.
+func (w *watcher) handleTranscoderDeactivated(vLog types.Log) {
+	if len(vLog.Topics) < 2 {
+		return
+	}
+	o := w.orchestratorByTopic(vLog.Topics[1])
+	if o == nil {
+		return
+	}
+	values, err := w.abis.BondingManager.Unpack("TranscoderDeactivated", vLog.Data)
+	if err != nil || len(values) == 0 {
+		w.log.Printf("failed to decode TranscoderDeactivated event: %v", err)
+		return
+	}
+	if round, ok := values[0].(*big.Int); ok && round.IsUint64() {
+		w.publishEvent(w.logEvent(vLog, "TranscoderDeactivated", o))
+		w.updateDeactivationRound(o, round.Uint64())
+	}
+}
+
+// refreshDeactivationRounds reads the deactivation round of every orchestrator from the BondingManager.
+func (w *watcher) refreshDeactivationRounds() {
+	if w.currentRound == 0 {
+		return // Past and pending deactivations can't be told apart before the round is known.
+	}
+	for _, o := range w.orchestrators {
+		w.refreshDeactivationRound(o)
+	}
+}
+
+// refreshDeactivationRound reads the orchestrator's deactivation round from the BondingManager,
+// along with its last reward round.
+func (w *watcher) refreshDeactivationRound(o *orchestrator) {
+	values, err := w.callContract(w.abis.BondingManager, w.net.Contracts.BondingManager, "getTranscoder", o.address)
+	if err != nil {
+		w.log.Printf("failed to fetch transcoder info of %s: %v", o.address.Hex(), err)
+		return
+	}
+	w.syncLastRewardRound(o, values)
+	round, err := outputBigInt(w.abis.BondingManager, "getTranscoder", values, "deactivationRound")
+	if err != nil {
+		w.log.Printf("%v", err)
+		return
+	}
+	if round.Sign() == 0 || round.Cmp(maxFutureRound) == 0 || !round.IsUint64() {
+		o.deactivationRound = 0
+		return
+	}
+	w.updateDeactivationRound(o, round.Uint64())
+}
+
+// updateDeactivationRound records a (pending) deactivation and sends its countdown alert once per round.
+func (w *watcher) updateDeactivationRound(o *orchestrator, round uint64) {
+	if w.currentRound != 0 && w.currentRound >= round {
+		if o.deactivationRound == round {
+			msg := fmt.Sprintf(
+				tr("🛑 Orchestrator %s left the active set in round %d."),
+				o.link(), round)
+			w.log.Println(msg)
+			w.orchestratorAlert(o, "deactivated", msg, 0xFF0000)
+		}
+		o.deactivationRound = 0
+		return
+	}
+	isNew := o.deactivationRound != round
+	if !isNew && o.deactivationAlertedRound == w.currentRound {
+		return
+	}
+	o.deactivationRound = round
+	o.deactivationAlertedRound = w.currentRound
+	var msg string
+	if isNew {
+		msg = fmt.Sprintf(
+			tr("⚠️ Orchestrator %s is scheduled to leave the active set in round %d."),
+			o.link(), round)
+		if w.currentRound != 0 {
+			msg += fmt.Sprintf(tr(" That is %d round(s) from now."), round-w.currentRound)
+		}
+	} else {
+		msg = fmt.Sprintf(
+			tr("⏳ Orchestrator %s leaves the active set in %d round(s), in round %d."),
+			o.link(), round-w.currentRound, round)
+	}
+	w.log.Println(msg)
+	w.orchestratorAlert(o, "deactivation_scheduled", msg, 0xFFA500)
+}
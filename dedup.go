@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// alertLimiter collapses repeated alerts and enforces alert rate limits.
+type alertLimiter struct {
+	mu     sync.Mutex
+	seen   map[string]*dedupEntry
+	recent map[string][]time.Time
+}
+
+// dedupEntry tracks an alert within its dedup window.
+type dedupEntry struct {
+	sent       time.Time
+	suppressed int
+}
+
+func newAlertLimiter() *alertLimiter {
+	return &alertLimiter{seen: make(map[string]*dedupEntry), recent: make(map[string][]time.Time)}
+}
+
+// dedupe reports whether an alert with the given key is sent, and how many identical alerts
+// were collapsed since it was last sent. Alerts are collapsed for window after one is sent.
+func (l *alertLimiter) dedupe(key string, window time.Duration, now time.Time) (send bool, repeats int) {
+	if l == nil || window <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for k, e := range l.seen {
+		// Forget alerts that stopped recurring; their collapsed repeats are dropped after a day.
+		if age := now.Sub(e.sent); (age >= window && e.suppressed == 0) || age >= 24*time.Hour {
+			delete(l.seen, k)
+		}
+	}
+	if e, ok := l.seen[key]; ok {
+		if now.Sub(e.sent) < window {
+			e.suppressed++
+			return false, 0
+		}
+		repeats = e.suppressed
+	}
+	l.seen[key] = &dedupEntry{sent: now}
+	return true, repeats
+}
+
+// allow reports whether another alert fits in the limit of alerts per minute for key, and
+// records it if so.
+func (l *alertLimiter) allow(key string, limit int, now time.Time) bool {
+	if l == nil || limit <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var kept []time.Time
+	for _, t := range l.recent[key] {
+		if now.Sub(t) < time.Minute {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) >= limit {
+		l.recent[key] = kept
+		return false
+	}
+	l.recent[key] = append(kept, now)
+	return true
+}
+
+// parseRateLimits parses per-channel rate limits of the form "channel=alerts per minute,...".
+func parseRateLimits(raw []string) (map[string]int, error) {
+	out := make(map[string]int)
+	for _, entry := range raw {
+		channel, limit, ok := strings.Cut(entry, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if !ok || err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, expected channel=alerts per minute", entry)
+		}
+		channel = strings.TrimSpace(channel)
+		if err := validateChannels([]string{channel}); err != nil {
+			return nil, err
+		}
+		out[channel] = n
+	}
+	return out, nil
+}
+
+// repeatNote describes how many identical alerts were collapsed into an alert.
+func repeatNote(repeats int, window time.Duration) string {
+	return fmt.Sprintf(" (repeated %d more time(s) within %s)", repeats, window)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{name: "none", raw: nil, want: map[string]int{}},
+		{
+			name: "several channels",
+			raw:  []string{"telegram=5", " sms = 1 ", "discord=0"},
+			want: map[string]int{"telegram": 5, "sms": 1, "discord": 0},
+		},
+		{name: "missing limit", raw: []string{"telegram"}, wantErr: true},
+		{name: "not a number", raw: []string{"telegram=five"}, wantErr: true},
+		{name: "negative limit", raw: []string{"telegram=-1"}, wantErr: true},
+		{name: "unknown channel", raw: []string{"fax=1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRateLimits(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRateLimits(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRateLimits(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// checkDelegatorClaims alerts when a watched delegator's lastClaimRound falls more than
+// maxClaimLag rounds behind the current round, and when it catches up again.
+func (w *watcher) checkDelegatorClaims() {
+	if w.currentRound == 0 || w.opts.maxClaimLag == 0 {
+		return
+	}
+	for _, delegator := range w.net.Delegators {
+		values, err := w.callContract(w.abis.BondingManager, w.net.Contracts.BondingManager, "getDelegator", delegator)
+		if err != nil {
+			w.log.Printf("failed to fetch delegator %s: %v", delegator.Hex(), err)
+			continue
+		}
+		lastClaim, err := outputBigInt(w.abis.BondingManager, "getDelegator", values, "lastClaimRound")
+		if err != nil || !lastClaim.IsUint64() {
+			w.log.Printf("failed to read lastClaimRound of %s: %v", delegator.Hex(), err)
+			continue
+		}
+		var lag uint64
+		if w.currentRound > lastClaim.Uint64() {
+			lag = w.currentRound - lastClaim.Uint64()
+		}
+		address := strings.ToLower(delegator.Hex())
+		lagging := lag > w.opts.maxClaimLag
+		if lagging == w.claimLagging[delegator] {
+			continue
+		}
+		w.claimLagging[delegator] = lagging
+		msg := fmt.Sprintf(
+			tr("✅ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) claimed earnings up to round %d."),
+			address, address, lastClaim.Uint64())
+		color, kind := 0x00FF00, "delegator_claimed"
+		if lagging {
+			msg = fmt.Sprintf(
+				tr("⚠️ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) last claimed earnings in round %d, %d rounds behind the current round %d."),
+				address, address, lastClaim.Uint64(), lag, w.currentRound)
+			color, kind = 0xFFA500, "delegator_claim_lag"
+		}
+		w.log.Println(msg)
+		w.alert(kind, msg, color)
+	}
+}
+
+// parseAddresses parses a list of hex addresses.
+func parseAddresses(raw []string) ([]common.Address, error) {
+	out := make([]common.Address, 0, len(raw))
+	for _, r := range raw {
+		if !common.IsHexAddress(r) {
+			return nil, fmt.Errorf("invalid address %q", r)
+		}
+		out = append(out, common.HexToAddress(r))
+	}
+	return out, nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const discordAPIURL = "https://discord.com/api/v10"
+
+// discordMutePermission is the permission members need to see and run /mute and /unmute, as
+// these silence every alert channel. Server admins can grant the commands to other roles in the
+// server's integration settings.
+var discordMutePermission int64 = discordgo.PermissionManageServer
+
+// discordDMs is false: the bot's commands can only be used in servers.
+var discordDMs = false
+
+// discordCommands are the slash commands registered by the Discord bot.
+var discordCommands = []*discordgo.ApplicationCommand{
+	{Name: "reward-status", Description: "Show whether the watched orchestrators called reward this round"},
+	{Name: "round", Description: "Show the current round and when it started"},
+	{Name: "last-reward", Description: "Show the last reward call of every orchestrator"},
+	{
+		Name:                     "mute",
+		Description:              "Silence all alerts for a while",
+		DefaultMemberPermissions: &discordMutePermission,
+		DMPermission:             &discordDMs,
+		Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "duration", Description: "How long to mute alerts, e.g. 6h or 30m", Required: true},
+		},
+	},
+	{Name: "unmute", Description: "Resume sending alerts", DefaultMemberPermissions: &discordMutePermission, DMPermission: &discordDMs},
+}
+
+// discordBot answers slash commands over the Discord gateway, so it needs no public endpoint.
+type discordBot struct {
+	guildID  string
+	watchers []*watcher
+	status   *statusBoard
+}
+
+// runDiscordBot connects the bot to the Discord gateway. The session keeps the connection alive
+// and reconnects or resumes it by itself.
+func runDiscordBot(token, guildID string, watchers []*watcher, status *statusBoard) {
+	b := &discordBot{guildID: guildID, watchers: watchers, status: status}
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		log.Printf("Discord bot: %v", err)
+		return
+	}
+	session.Identify.Intents = discordgo.IntentsNone // Interactions are delivered without any intents.
+	session.AddHandler(b.ready)
+	session.AddHandler(b.interaction)
+	for {
+		err := session.Open()
+		if err == nil {
+			return
+		}
+		log.Printf("Discord bot failed to connect: %v", err)
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// ready registers the slash commands once the bot is connected.
+func (b *discordBot) ready(s *discordgo.Session, r *discordgo.Ready) {
+	if _, err := s.ApplicationCommandBulkOverwrite(r.Application.ID, b.guildID, discordCommands); err != nil {
+		log.Printf("Discord bot: failed to register slash commands: %v", err)
+		return
+	}
+	log.Printf("Discord bot connected, slash commands registered")
+}
+
+// interaction answers a slash command.
+func (b *discordBot) interaction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	data := i.ApplicationCommandData()
+	var arg string
+	for _, o := range data.Options {
+		arg = fmt.Sprint(o.Value)
+	}
+	user := "an unknown Discord user"
+	if u := interactionUser(i.Interaction); u != nil {
+		user = fmt.Sprintf("Discord user %s (%s)", u.Username, u.ID)
+	}
+	reply := runBotCommand(b.watchers, b.status, user, data.Name, arg)
+	if len(reply) > 2000 {
+		reply = reply[:1997] + "..."
+	}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         reply,
+			AllowedMentions: &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}},
+		},
+	})
+	if err != nil {
+		log.Printf("Discord bot: failed to answer /%s: %v", data.Name, err)
+	}
+}
+
+// interactionUser returns the user who ran a command: the member in a server, or the user in a DM.
+func interactionUser(i *discordgo.Interaction) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// discordAPI calls the Discord REST API as the bot and decodes the response into result, if given.
+func discordAPI(token, method, path string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, discordAPIURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("discord API returned HTTP %d: %s", resp.StatusCode, msg)
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+// Discord channel types that matter for round threads.
+const (
+	discordForumChannel = 15
+	discordMediaChannel = 16
+	discordPublicThread = 11
+)
+
+// discordWebhookThread returns the ID of the active thread with the given name in the channel of a
+// webhook, creating it if needed. Webhooks can only create threads in forum channels, so the bot
+// creates them elsewhere. For forum channels it returns "" unless the thread already exists, and
+// the webhook creates the thread itself.
+func discordWebhookThread(token, webhookURL, name string) (string, error) {
+	var hook struct {
+		ChannelID string `json:"channel_id"`
+		GuildID   string `json:"guild_id"`
+	}
+	resp, err := alertHTTPClient.Get(webhookURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("discord webhook returned HTTP %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return "", err
+	}
+
+	var active struct {
+		Threads []struct {
+			ID       string `json:"id"`
+			ParentID string `json:"parent_id"`
+			Name     string `json:"name"`
+		} `json:"threads"`
+	}
+	if err := discordAPI(token, http.MethodGet, fmt.Sprintf("/guilds/%s/threads/active", hook.GuildID), nil, &active); err != nil {
+		return "", err
+	}
+	for _, t := range active.Threads {
+		if t.ParentID == hook.ChannelID && t.Name == name {
+			return t.ID, nil
+		}
+	}
+
+	var channel struct {
+		Type int `json:"type"`
+	}
+	if err := discordAPI(token, http.MethodGet, "/channels/"+hook.ChannelID, nil, &channel); err != nil {
+		return "", err
+	}
+	if channel.Type == discordForumChannel || channel.Type == discordMediaChannel {
+		return "", nil
+	}
+	var thread struct {
+		ID string `json:"id"`
+	}
+	create := map[string]interface{}{
+		"name":                  name,
+		"type":                  discordPublicThread,
+		"auto_archive_duration": 10080, // A week, so a round's thread stays open until the round ends.
+	}
+	if err := discordAPI(token, http.MethodPost, fmt.Sprintf("/channels/%s/threads", hook.ChannelID), create, &thread); err != nil {
+		return "", err
+	}
+	return thread.ID, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// watcherDump is the full internal state of a watcher, logged for debugging live instances.
+type watcherDump struct {
+	watcherStatus
+	// RPCURLs are the configured endpoints; their health scores are under rpcs.
+	RPCURLs            []string           `json:"rpcUrls"`
+	Subscriptions      int                `json:"subscriptions"`
+	NetworkRewardStall stallDetectorDump  `json:"networkRewardStall"`
+	RoundStall         stallDetectorDump  `json:"roundStall"`
+	OrchestratorState  []orchestratorDump `json:"orchestratorState"`
+	Notifier           notifierDump       `json:"notifier"`
+}
+
+// notifierDump is the debug representation of the alerts a notifier holds back or limits.
+type notifierDump struct {
+	// QuietQueue holds the alerts suppressed during quiet hours, sent when the window ends.
+	QuietQueue []queuedAlertDump `json:"quietQueue"`
+	// EmailDigest holds the alerts waiting for the next email digest.
+	EmailDigest []digestEntryDump `json:"emailDigest"`
+	// Dedup lists the alerts within their dedup window and how many repeats were collapsed.
+	Dedup []dedupDump `json:"dedup"`
+	// RateLimits counts the alerts sent within the last minute per rate limit key.
+	RateLimits map[string]int `json:"rateLimits"`
+}
+
+type queuedAlertDump struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type digestEntryDump struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+type dedupDump struct {
+	Key        string    `json:"key"`
+	Sent       time.Time `json:"sent"`
+	Suppressed int       `json:"suppressed"`
+}
+
+// orchestratorDump is the debug representation of an orchestrator's state.
+type orchestratorDump struct {
+	Address             string    `json:"address"`
+	RewardCaller        string    `json:"rewardCaller"`
+	SentWarning         bool      `json:"sentWarning"`
+	RoundFeesWei        string    `json:"roundFeesWei"`
+	RoundTickets        int       `json:"roundTickets"`
+	RoundTreasuryWei    string    `json:"roundTreasuryWei"`
+	TreasuryTotalWei    string    `json:"treasuryTotalWei"`
+	RewardGasHistory    []uint64  `json:"rewardGasHistory"`
+	CallerNonceGapSince time.Time `json:"callerNonceGapSince"`
+	DeactivationRound   uint64    `json:"deactivationRound"`
+	ServiceURI          string    `json:"serviceUri"`
+	ServiceURIDown      bool      `json:"serviceUriDown"`
+}
+
+// stallDetectorDump is the debug representation of a stallDetector.
+type stallDetectorDump struct {
+	Timeout  string    `json:"timeout"`
+	LastSeen time.Time `json:"lastSeen"`
+	Alerted  bool      `json:"alerted"`
+}
+
+func dumpStallDetector(d *stallDetector) stallDetectorDump {
+	return stallDetectorDump{Timeout: d.timeout.String(), LastSeen: d.last, Alerted: d.alerted}
+}
+
+// dumpNotifier returns the quiet hours queue, email digest, dedup and rate limit state of n.
+func dumpNotifier(n *notifier) notifierDump {
+	var d notifierDump
+	if q := n.quietQueue; q != nil {
+		q.mu.Lock()
+		for _, qa := range q.alerts {
+			d.QuietQueue = append(d.QuietQueue, queuedAlertDump{Type: qa.a.Type, Message: qa.message})
+		}
+		q.mu.Unlock()
+	}
+	if e := n.emailDigest; e != nil {
+		e.mu.Lock()
+		for _, entry := range e.entries {
+			d.EmailDigest = append(d.EmailDigest, digestEntryDump{Time: entry.time, Message: entry.message})
+		}
+		e.mu.Unlock()
+	}
+	if l := n.limiter; l != nil {
+		l.mu.Lock()
+		for key, e := range l.seen {
+			d.Dedup = append(d.Dedup, dedupDump{Key: key, Sent: e.sent, Suppressed: e.suppressed})
+		}
+		d.RateLimits = make(map[string]int, len(l.recent))
+		for key, sent := range l.recent {
+			for _, t := range sent {
+				if time.Since(t) < time.Minute {
+					d.RateLimits[key]++
+				}
+			}
+		}
+		l.mu.Unlock()
+	}
+	return d
+}
+
+// dump returns the watcher's full state. It must run on the watcher's goroutine.
+func (w *watcher) dump() watcherDump {
+	rpcs := make([]string, 0, len(w.net.RPCs))
+	for _, rpc := range w.net.RPCs {
+		rpcs = append(rpcs, maskRPCURL(rpc))
+	}
+	orchestrators := make([]orchestratorDump, 0, len(w.orchestrators))
+	for _, o := range w.orchestrators {
+		orchestrators = append(orchestrators, orchestratorDump{
+			Address:             o.address.Hex(),
+			RewardCaller:        o.rewardCaller.Hex(),
+			SentWarning:         o.sentWarning,
+			RoundFeesWei:        o.roundFees.String(),
+			RoundTickets:        o.roundTickets,
+			RoundTreasuryWei:    o.roundTreasury.String(),
+			TreasuryTotalWei:    o.treasuryTotal.String(),
+			RewardGasHistory:    o.rewardGas.history,
+			CallerNonceGapSince: o.nonceGap.since,
+			DeactivationRound:   o.deactivationRound,
+			ServiceURI:          o.serviceURI.lastServiceURI,
+			ServiceURIDown:      o.serviceURI.down,
+		})
+	}
+	return watcherDump{
+		watcherStatus:      w.snapshot(),
+		RPCURLs:            rpcs,
+		Subscriptions:      len(w.subs),
+		NetworkRewardStall: dumpStallDetector(w.networkRewardStall),
+		RoundStall:         dumpStallDetector(w.roundStall),
+		OrchestratorState:  orchestrators,
+		Notifier:           dumpNotifier(w.net.Notifier),
+	}
+}
+
+// dumpState logs the full state of all watchers as JSON. Watchers that are busy reconnecting
+// don't answer in time and are reported with their last published status instead.
+func dumpState(watchers []*watcher, status *statusBoard) {
+	for _, w := range watchers {
+		reply := make(chan watcherDump, 1)
+		var v interface{}
+		select {
+		case w.control <- func() { reply <- w.dump() }:
+			v = <-reply
+		case <-time.After(2 * time.Second):
+			status.mu.Lock()
+			v = map[string]interface{}{"unresponsive": true, "lastStatus": status.statuses[w.net.Name]}
+			status.mu.Unlock()
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			log.Printf("failed to encode state dump: %v", err)
+			continue
+		}
+		log.Printf("State dump %s: %s", w.net.Name, data)
+	}
+}
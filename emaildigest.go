@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// emailDigest collects informational alerts that are sent as a single periodic email.
+type emailDigest struct {
+	mu        sync.Mutex
+	entries   []digestEntry
+	scheduled bool
+}
+
+type digestEntry struct {
+	time    time.Time
+	message string
+}
+
+func newEmailDigest() *emailDigest {
+	return &emailDigest{}
+}
+
+// digestInterval returns how often informational alerts are batched into a digest, 0 to email
+// every alert on its own.
+func (c EmailConfig) digestInterval() (time.Duration, error) {
+	if c.Digest == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(c.Digest)
+	if err != nil || interval < time.Minute {
+		return 0, fmt.Errorf("invalid email digest interval %q, expected a duration of at least 1m", c.Digest)
+	}
+	return interval, nil
+}
+
+// nextDigest returns when the digest covering t is sent. Digests are aligned to local midnight,
+// so an hourly digest goes out on the hour and a daily digest at midnight.
+func nextDigest(t time.Time, interval time.Duration) time.Time {
+	t = t.In(location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+	if interval > 24*time.Hour {
+		return t.Add(interval)
+	}
+	return midnight.Add((t.Sub(midnight)/interval + 1) * interval)
+}
+
+// addToDigest queues an alert for the next email digest.
+func (n *notifier) addToDigest(message string, interval time.Duration) {
+	d := n.emailDigest
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	d.entries = append(d.entries, digestEntry{now, message})
+	if !d.scheduled {
+		d.scheduled = true
+		time.AfterFunc(time.Until(nextDigest(now, interval)), n.flushEmailDigest)
+	}
+}
+
+// flushEmailDigest emails the queued informational alerts as one digest.
+func (n *notifier) flushEmailDigest() {
+	d := n.emailDigest
+	d.mu.Lock()
+	entries := d.entries
+	d.entries, d.scheduled = nil, false
+	d.mu.Unlock()
+	if len(entries) == 0 {
+		return
+	}
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		message := strings.TrimSpace(e.message)
+		if !n.Timestamps {
+			message = fmt.Sprintf(tr("🕒 %s\n%s"), formatDate(e.time), message)
+		}
+		parts = append(parts, message)
+	}
+	subject := fmt.Sprintf(tr("%s digest: %d alert(s)"), n.emailSubject(), len(entries))
+	result := "ok"
+	if err := n.sendEmail(subject, strings.Join(parts, "\n\n"), 0x808080, severityInfo); err != nil {
+		log.Printf("failed to send email digest: %v", err)
+		result = "error"
+	}
+	metrics.inc("reward_watcher_alerts_total", "channel", "email_digest", "result", result)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultEmailTemplate renders alerts like the plain HTML emails sent before templates existed.
+const defaultEmailTemplate = `<html><body><p>{{.Body}}</p>{{if .Footer}}<p><small>{{.Footer}}</small></p>{{end}}</body></html>`
+
+// emailTemplateData is available to HTML email templates.
+type emailTemplateData struct {
+	Subject  string
+	Title    string
+	Body     htmltemplate.HTML // The alert as HTML, with clickable links.
+	Text     string            // The alert as plain text.
+	Color    string            // The alert color as #rrggbb, e.g. for a header bar.
+	Severity string
+	Footer   string
+	Time     string
+}
+
+// loadEmailTemplate parses an HTML email template file, or the default template if path is empty.
+func loadEmailTemplate(path string) (*htmltemplate.Template, error) {
+	if path == "" {
+		return htmltemplate.Must(htmltemplate.New("email").Parse(defaultEmailTemplate)), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email template: %v", err)
+	}
+	tmpl, err := htmltemplate.New("email").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email template %s: %v", path, err)
+	}
+	return tmpl, nil
+}
+
+// markdownToText converts a markdown-formatted message to plain text, with links spelled out.
+func markdownToText(message string) string {
+	return markdownLinkRe.ReplaceAllString(message, "$1 ($2)")
+}
+
+// renderEmail renders an alert into the HTML email template and a plain text alternative.
+func (n *notifier) renderEmail(subject, message string, color int, severity string) (text, html string, err error) {
+	tmpl := n.Email.template
+	if tmpl == nil {
+		if tmpl, err = loadEmailTemplate(""); err != nil {
+			return "", "", err
+		}
+	}
+	message = strings.TrimSpace(message)
+	text = markdownToText(message)
+	if n.Branding.Footer != "" {
+		text += "\n\n-- \n" + n.Branding.Footer
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, emailTemplateData{
+		Subject:  subject,
+		Title:    n.emailSubject(),
+		Body:     htmltemplate.HTML(markdownBodyToHTML(message)),
+		Text:     text,
+		Color:    fmt.Sprintf("#%06X", color),
+		Severity: severity,
+		Footer:   n.Branding.Footer,
+		Time:     formatDate(time.Now()),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render email template: %v", err)
+	}
+	return text, b.String(), nil
+}
+
+// multipartAlternative builds a multipart/alternative body with a plain text and an HTML part,
+// so clients that block HTML still show the alert. It returns the body and its content type.
+func multipartAlternative(text, html string) (string, string, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", "", err
+		}
+		// Quoted-printable keeps lines within the SMTP line length limit.
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return "", "", err
+		}
+		if err := qp.Close(); err != nil {
+			return "", "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", "", err
+	}
+	return b.String(), "multipart/alternative; boundary=" + w.Boundary(), nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// escalationStage adds channels to the missed-reward alert once the reward has been missing for
+// After since the first warning of the round.
+type escalationStage struct {
+	After    time.Duration
+	Channels []string
+}
+
+// parseEscalation parses an escalation ladder of the form "0s=discord;2h=telegram;4h=pagerduty,sms".
+// Each stage adds its channels to those of the earlier stages.
+func parseEscalation(raw string) ([]escalationStage, error) {
+	var stages []escalationStage
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		after, channels, ok := strings.Cut(rule, "=")
+		d, err := time.ParseDuration(strings.TrimSpace(after))
+		if !ok || err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid escalation stage %q, expected duration=channel[,channel...]", rule)
+		}
+		stage := escalationStage{After: d, Channels: splitCSV(channels)}
+		if err := validateRoutes(map[string][]string{after: stage.Channels}); err != nil {
+			return nil, err
+		}
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool { return stages[i].After < stages[j].After })
+	return stages, nil
+}
+
+// escalationLevel returns how many stages have been reached after elapsed, and the channels
+// of those stages.
+func escalationLevel(stages []escalationStage, elapsed time.Duration) (level int, channels []string) {
+	for _, s := range stages {
+		if elapsed < s.After {
+			break
+		}
+		level++
+		channels = append(channels, s.Channels...)
+	}
+	return level, channels
+}
+
+// parseWarningStages parses the percentages of the round at which the missed-reward warning
+// fires, e.g. "50,75,90".
+func parseWarningStages(raw string) ([]float64, error) {
+	var stages []float64
+	for _, s := range splitCSV(raw) {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil || percent <= 0 || percent >= 100 {
+			return nil, fmt.Errorf("invalid warning stage %q, expected a percentage between 0 and 100", s)
+		}
+		stages = append(stages, percent)
+	}
+	sort.Float64s(stages)
+	return stages, nil
+}
+
+// warningStage returns how many warning stages have been reached at progress percent of the round.
+func warningStage(stages []float64, progress float64) int {
+	stage := 0
+	for _, threshold := range stages {
+		if progress >= threshold {
+			stage++
+		}
+	}
+	return stage
+}
+
+// warningTier returns the message, color and severity of the missed-reward warning at a stage:
+// the last stage is critical, the one before it a warning and earlier stages informational.
+func warningTier(stage, stages int) (format string, color int, severity string) {
+	switch {
+	case stage >= stages:
+		return tr("❌ No reward called for %s in round %d after %s."), 0xFF0000, severityCritical
+	case stage == stages-1:
+		return tr("⚠️ No reward called for %s in round %d yet after %s."), 0xFFA500, severityWarning
+	}
+	return tr("⏳ No reward called for %s in round %d yet after %s."), 0xFFFF00, severityInfo
+}
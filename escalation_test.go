@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEscalation(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []escalationStage
+		wantErr bool
+	}{
+		{name: "empty", raw: ""},
+		{
+			name: "sorted by delay",
+			raw:  "4h=pagerduty,sms; 0s=discord;2h=telegram",
+			want: []escalationStage{
+				{After: 0, Channels: []string{"discord"}},
+				{After: 2 * time.Hour, Channels: []string{"telegram"}},
+				{After: 4 * time.Hour, Channels: []string{"pagerduty", "sms"}},
+			},
+		},
+		{name: "invalid duration", raw: "2 hours=telegram", wantErr: true},
+		{name: "negative duration", raw: "-1h=telegram", wantErr: true},
+		{name: "missing channels separator", raw: "2h", wantErr: true},
+		{name: "unknown channel", raw: "2h=fax", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEscalation(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEscalation(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEscalation(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscalationLevel(t *testing.T) {
+	stages := []escalationStage{
+		{After: 0, Channels: []string{"discord"}},
+		{After: 2 * time.Hour, Channels: []string{"telegram"}},
+		{After: 4 * time.Hour, Channels: []string{"pagerduty"}},
+	}
+	tests := []struct {
+		elapsed      time.Duration
+		wantLevel    int
+		wantChannels []string
+	}{
+		{0, 1, []string{"discord"}},
+		{2*time.Hour - time.Second, 1, []string{"discord"}},
+		{2 * time.Hour, 2, []string{"discord", "telegram"}},
+		{5 * time.Hour, 3, []string{"discord", "telegram", "pagerduty"}},
+	}
+	for _, tt := range tests {
+		level, channels := escalationLevel(stages, tt.elapsed)
+		if level != tt.wantLevel || !reflect.DeepEqual(channels, tt.wantChannels) {
+			t.Errorf("escalationLevel(%s) = %d, %v, want %d, %v", tt.elapsed, level, channels, tt.wantLevel, tt.wantChannels)
+		}
+	}
+}
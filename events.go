@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// event is a structured record of an alert or of an on-chain event observed by the watcher. It
+// is posted to the generic webhook and published to the event sinks.
+type event struct {
+	// Kind is "alert" for alerts, "chain" for on-chain events and "lifecycle" for changes of the
+	// watcher itself, such as RPC connections.
+	Kind string `json:"kind"`
+	// Type identifies the event, e.g. "reward", "reward_missed" or "new_round" for alerts and the
+	// contract event name, e.g. "Reward", for on-chain events.
+	Type         string `json:"type"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message,omitempty"`
+	Color        int    `json:"color,omitempty"`
+	Orchestrator string `json:"orchestrator,omitempty"`
+	Round        uint64 `json:"round,omitempty"`
+	Block        uint64 `json:"block,omitempty"`
+	Tx           string `json:"tx,omitempty"`
+	// Amount is the token amount of the event in whole units, e.g. the LPT minted by a reward call.
+	Amount    string `json:"amount,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// newAlertEvent builds the event of an alert.
+func newAlertEvent(a alert, label string) event {
+	e := event{
+		Kind:      "alert",
+		Type:      a.Type,
+		Severity:  a.severity(),
+		Message:   a.Message,
+		Color:     a.Color,
+		Round:     a.Round,
+		Block:     a.Block,
+		Tx:        a.Tx,
+		Label:     label,
+		Timestamp: time.Now().Unix(),
+	}
+	if e.Type == "" {
+		e.Type = "alert"
+	}
+	if a.Orchestrator != (common.Address{}) {
+		e.Orchestrator = strings.ToLower(a.Orchestrator.Hex())
+	}
+	return e
+}
+
+// eventSinks lists the supported event sinks, which receive every alert and on-chain event.
+var eventSinks = []struct{ id, name string }{
+	{"mqtt", "MQTT"},
+	{"kafka", "Kafka"},
+	{"nats", "NATS"},
+	{"syslog", "Syslog"},
+}
+
+// sinkEnabled reports whether the event sink is configured.
+func (n *notifier) sinkEnabled(sink string) bool {
+	switch sink {
+	case "mqtt":
+		return n.MQTT.Broker != ""
+	case "kafka":
+		return n.Kafka.complete()
+	case "nats":
+		return n.NATS.URL != ""
+	case "syslog":
+		return n.Syslog.Address != ""
+	}
+	return false
+}
+
+// publish queues an event for all configured event sinks. It never blocks on a sink.
+func (n *notifier) publish(e event) {
+	if e.Label == "" {
+		e.Label = n.Label
+	}
+	for _, sink := range eventSinks {
+		if n.sinkEnabled(sink.id) {
+			n.sinkPublisher(sink.id, sink.name).enqueue(e)
+		}
+	}
+}
+
+// sinkPublisher returns the publisher of the notifier's configuration of an event sink.
+func (n *notifier) sinkPublisher(id, name string) *sinkPublisher {
+	var cfg interface{}
+	var dial func() (sinkConn, error)
+	switch id {
+	case "mqtt":
+		c := n.MQTT
+		cfg, dial = c, func() (sinkConn, error) { return dialMQTT(c) }
+	case "kafka":
+		c := n.Kafka
+		cfg, dial = c, func() (sinkConn, error) { return dialKafka(c) }
+	case "nats":
+		c := n.NATS
+		cfg, dial = c, func() (sinkConn, error) { return dialNATS(c) }
+	case "syslog":
+		c := n.Syslog
+		cfg, dial = c, func() (sinkConn, error) { return dialSyslog(c) }
+	}
+	return sinkPublisherFor(id, name, cfg, dial)
+}
+
+// sinkConn is a long-lived connection to an event sink.
+type sinkConn interface {
+	publish(e event) error
+	Close() error
+}
+
+// sinkQueueSize bounds the events buffered per sink while it is slow or down. Further events
+// are dropped, so a failing sink never holds up the monitoring loop.
+const sinkQueueSize = 256
+
+// sinkPublisher publishes the events of one sink configuration from its own goroutine over a
+// long-lived connection, reconnecting when publishing fails.
+type sinkPublisher struct {
+	id, name string
+	dial     func() (sinkConn, error)
+	queue    chan sinkJob
+}
+
+// sinkJob is a queued event, or with flushed set, a request to report when the queue before it
+// has been published.
+type sinkJob struct {
+	event   event
+	flushed chan struct{}
+}
+
+// sinkPublishers holds one publisher per sink configuration, shared by all watchers using it.
+var sinkPublishers = struct {
+	sync.Mutex
+	m map[string]*sinkPublisher
+}{m: make(map[string]*sinkPublisher)}
+
+// sinkPublisherFor returns the publisher of a sink configuration, starting it on first use.
+func sinkPublisherFor(id, name string, cfg interface{}, dial func() (sinkConn, error)) *sinkPublisher {
+	data, _ := json.Marshal(cfg)
+	key := id + "/" + string(data)
+	sinkPublishers.Lock()
+	defer sinkPublishers.Unlock()
+	if p, ok := sinkPublishers.m[key]; ok {
+		return p
+	}
+	p := &sinkPublisher{id: id, name: name, dial: dial, queue: make(chan sinkJob, sinkQueueSize)}
+	sinkPublishers.m[key] = p
+	go p.run()
+	return p
+}
+
+// enqueue queues an event, dropping it when the queue is full.
+func (p *sinkPublisher) enqueue(e event) {
+	select {
+	case p.queue <- sinkJob{event: e}:
+	default:
+		log.Printf("%s publish error: %d events queued, dropping %s event", p.name, sinkQueueSize, e.Type)
+		metrics.inc("reward_watcher_alerts_total", "channel", p.id, "result", "dropped")
+	}
+}
+
+func (p *sinkPublisher) run() {
+	var conn sinkConn
+	for job := range p.queue {
+		if job.flushed != nil {
+			close(job.flushed)
+			continue
+		}
+		var err error
+		// Retry once on a new connection, as the sink may have closed an idle one.
+		for attempt := 0; attempt < 2; attempt++ {
+			if conn == nil {
+				if conn, err = p.dial(); err != nil {
+					conn = nil
+					break
+				}
+			}
+			if err = conn.publish(job.event); err == nil {
+				break
+			}
+			conn.Close()
+			conn = nil
+		}
+		if err != nil {
+			log.Printf("%s publish error: %v", p.name, err)
+		}
+		recordChannelResult(p.id, err)
+	}
+}
+
+// flushEventSinks waits up to timeout for the queued events to be published, e.g. before the
+// process exits.
+func flushEventSinks(timeout time.Duration) {
+	sinkPublishers.Lock()
+	publishers := make([]*sinkPublisher, 0, len(sinkPublishers.m))
+	for _, p := range sinkPublishers.m {
+		publishers = append(publishers, p)
+	}
+	sinkPublishers.Unlock()
+	deadline := time.After(timeout)
+	for _, p := range publishers {
+		flushed := make(chan struct{})
+		select {
+		case p.queue <- sinkJob{flushed: flushed}:
+		case <-deadline:
+			return
+		}
+		select {
+		case <-flushed:
+		case <-deadline:
+			return
+		}
+	}
+}
+
+// publishEvent publishes an observed on-chain event to the event sinks. Events replayed while
+// catching up silently were already published by the previous run.
+func (w *watcher) publishEvent(e event) {
+	if w.silent {
+		return
+	}
+	e.Kind = "chain"
+	e.Severity = "info"
+	if e.Round == 0 {
+		e.Round = w.currentRound
+	}
+	if e.Timestamp == 0 {
+		e.Timestamp = time.Now().Unix()
+	}
+	w.net.Notifier.publish(e)
+}
+
+// publishLifecycle publishes a change of the watcher's own state to the event sinks.
+func (w *watcher) publishLifecycle(kind, severity, message string) {
+	w.net.Notifier.publish(event{
+		Kind:      "lifecycle",
+		Type:      kind,
+		Severity:  severity,
+		Message:   message,
+		Round:     w.currentRound,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// logEvent returns the event of an on-chain log.
+func (w *watcher) logEvent(vLog types.Log, name string, o *orchestrator) event {
+	e := event{
+		Type:      name,
+		Block:     vLog.BlockNumber,
+		Tx:        vLog.TxHash.Hex(),
+		Timestamp: w.eventTime(vLog).Unix(),
+	}
+	if o != nil {
+		e.Orchestrator = strings.ToLower(o.address.Hex())
+	}
+	return e
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSinkConn records the events published over it and fails its first publishes.
+type fakeSinkConn struct {
+	mu        *sync.Mutex
+	published *[]string
+	failures  int
+	closed    bool
+}
+
+func (c *fakeSinkConn) publish(e event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errors.New("use of closed connection")
+	}
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("connection reset by peer")
+	}
+	*c.published = append(*c.published, e.Type)
+	return nil
+}
+
+func (c *fakeSinkConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func TestSinkPublisher(t *testing.T) {
+	tests := []struct {
+		name string
+		// dials are the results of successive dials: a failing dial, or the number of
+		// publishes the connection fails before it works.
+		dials     []interface{}
+		events    []string
+		want      []string
+		wantDials int
+	}{
+		{
+			name:      "one connection for all events",
+			dials:     []interface{}{0},
+			events:    []string{"a", "b", "c"},
+			want:      []string{"a", "b", "c"},
+			wantDials: 1,
+		},
+		{
+			name:      "retried once on a new connection",
+			dials:     []interface{}{1, 0},
+			events:    []string{"a", "b"},
+			want:      []string{"a", "b"},
+			wantDials: 2,
+		},
+		{
+			name:      "dropped after the retry fails",
+			dials:     []interface{}{1, 1, 0},
+			events:    []string{"a", "b"},
+			want:      []string{"b"},
+			wantDials: 3,
+		},
+		{
+			name:      "redialed after a failed dial",
+			dials:     []interface{}{errors.New("connection refused"), 0},
+			events:    []string{"a", "b"},
+			want:      []string{"b"},
+			wantDials: 2,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var published []string
+			dials := 0
+			dial := func() (sinkConn, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if dials >= len(tt.dials) {
+					return nil, errors.New("no more connections")
+				}
+				d := tt.dials[dials]
+				dials++
+				if err, ok := d.(error); ok {
+					return nil, err
+				}
+				return &fakeSinkConn{mu: &mu, published: &published, failures: d.(int)}, nil
+			}
+			p := sinkPublisherFor("test", "Test", fmt.Sprintf("%s-%d", t.Name(), i), dial)
+			for _, kind := range tt.events {
+				p.enqueue(event{Type: kind})
+			}
+			flushEventSinks(5 * time.Second)
+			mu.Lock()
+			defer mu.Unlock()
+			if fmt.Sprint(published) != fmt.Sprint(tt.want) || dials != tt.wantDials {
+				t.Errorf("published %v over %d dials, want %v over %d", published, dials, tt.want, tt.wantDials)
+			}
+		})
+	}
+}
+
+func TestSinkPublisherEnqueueNeverBlocks(t *testing.T) {
+	// A publisher without a running worker, as if its sink hung on the first event.
+	p := &sinkPublisher{id: "test", name: "Test", queue: make(chan sinkJob, sinkQueueSize)}
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < sinkQueueSize+10; i++ {
+			p.enqueue(event{Type: "reward"})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("enqueue blocked on a full queue")
+	}
+	if len(p.queue) != sinkQueueSize {
+		t.Errorf("queued %d events, want %d", len(p.queue), sinkQueueSize)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// execTimeout bounds how long an alert command may run before it is killed.
+const execTimeout = 30 * time.Second
+
+// runAlertCommand runs a shell command for an alert, with the alert event as JSON on stdin and
+// its main fields in environment variables.
+func runAlertCommand(command string, e event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"EVENT_KIND="+e.Kind,
+		"EVENT_TYPE="+e.Type,
+		"SEVERITY="+e.Severity,
+		"MESSAGE="+e.Message,
+		"ORCHESTRATOR="+e.Orchestrator,
+		fmt.Sprintf("ROUND=%d", e.Round),
+		fmt.Sprintf("BLOCK=%d", e.Block),
+		"TX="+e.Tx,
+		"LABEL="+e.Label,
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("alert command timed out after %s", execTimeout)
+		}
+		return fmt.Errorf("alert command failed: %v: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// exportRecord is a single taxable income event written to the accounting export.
+type exportRecord struct {
+	Time     time.Time
+	Kind     string // "reward" or "fee"
+	Amount   *big.Int
+	Currency string // "LPT" or "ETH"
+	PriceUSD float64
+	TxHash   string
+	Round    uint64
+}
+
+// exportFormats maps supported export formats to their CSV header.
+var exportFormats = map[string][]string{
+	"generic": {"Date", "Type", "Round", "Amount", "Currency", "Price USD", "Value USD", "TxHash"},
+	"koinly": {"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+		"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"},
+}
+
+// exportWriter appends income events to a CSV file in a tax-tool friendly format.
+type exportWriter struct {
+	mu     sync.Mutex
+	path   string
+	format string
+}
+
+// newExportWriter validates the export format and returns a writer for the given path.
+func newExportWriter(path, format string) (*exportWriter, error) {
+	if _, ok := exportFormats[format]; !ok {
+		return nil, fmt.Errorf("unknown export format %q (supported: generic, koinly)", format)
+	}
+	return &exportWriter{path: path, format: format}, nil
+}
+
+// write appends a record to the export file, writing the header when the file is new.
+func (w *exportWriter) write(rec exportRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, statErr := os.Stat(w.path)
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	cw := csv.NewWriter(f)
+	if os.IsNotExist(statErr) {
+		if err := cw.Write(exportFormats[w.format]); err != nil {
+			return err
+		}
+	}
+	amount := formatUnits(rec.Amount, 18, 18)
+	amountF, _ := strconv.ParseFloat(amount, 64)
+	value := strconv.FormatFloat(amountF*rec.PriceUSD, 'f', 2, 64)
+	var row []string
+	switch w.format {
+	case "koinly":
+		label := "staking"
+		if rec.Kind == "fee" {
+			label = "income"
+		}
+		row = []string{
+			rec.Time.UTC().Format("2006-01-02 15:04 UTC"), "", "", amount, rec.Currency,
+			"", "", value, "USD", label, fmt.Sprintf("Livepeer %s in round %d", rec.Kind, rec.Round), rec.TxHash,
+		}
+	default:
+		row = []string{
+			rec.Time.UTC().Format(time.RFC3339), rec.Kind, strconv.FormatUint(rec.Round, 10), amount, rec.Currency,
+			strconv.FormatFloat(rec.PriceUSD, 'f', 4, 64), value, rec.TxHash,
+		}
+	}
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportEvent prices an income event at the current market rate and appends it to the export.
+func (w *exportWriter) exportEvent(kind string, round uint64, amount *big.Int, txHash string) {
+	currency, coinID := "LPT", coinGeckoLPT
+	if kind == "fee" {
+		currency, coinID = "ETH", coinGeckoETH
+	}
+	price, err := fetchPrice(coinID, "usd")
+	if err != nil {
+		log.Printf("failed to fetch %s price for export: %v", currency, err)
+	}
+	rec := exportRecord{
+		Time:     time.Now(),
+		Kind:     kind,
+		Amount:   amount,
+		Currency: currency,
+		PriceUSD: price,
+		TxHash:   txHash,
+		Round:    round,
+	}
+	if err := w.write(rec); err != nil {
+		log.Printf("failed to write export record: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseFinalCall parses how long before the round ends the final-call alert fires, as a duration
+// (e.g. 30m) or a number of L1 blocks (e.g. 150blocks).
+func parseFinalCall(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+	if blocks, ok := strings.CutSuffix(raw, "blocks"); ok {
+		n, err := strconv.ParseUint(strings.TrimSpace(blocks), 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid block count %q", raw)
+		}
+		return time.Duration(n) * l1BlockTime, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid final call %q, expected a duration like 30m or a block count like 150blocks", raw)
+	}
+	return d, nil
+}
+
+// checkFinalCall sends the final-call alert for orchestrators that still haven't called reward
+// when less than --final-call remains in the round. Until then it arms a timer for the moment
+// the final call is due whenever the next periodic check would be too late.
+func (w *watcher) checkFinalCall() {
+	if w.opts.finalCall <= 0 || w.currentRound == 0 {
+		return
+	}
+	pending := false
+	for _, o := range w.orchestrators {
+		pending = pending || !o.rewardCalled && !o.finalCallSent
+	}
+	if !pending {
+		return
+	}
+	position, ok := w.roundPosition()
+	if !ok {
+		return
+	}
+	remaining := position.remaining()
+	if wait := remaining - w.opts.finalCall; wait > 0 {
+		if wait < w.opts.checkInterval {
+			w.after(wait, w.checkFinalCall)
+		}
+		return
+	}
+	for _, o := range w.orchestrators {
+		if o.rewardCalled || o.finalCallSent {
+			continue
+		}
+		msg := fmt.Sprintf(
+			tr("🚨 Last chance: %s has still not called reward in round %d and the round ends in approximately %s."),
+			o.link(), w.currentRound, formatDuration(remaining.Round(time.Minute)))
+		w.log.Println(msg)
+		// Always critical and sent to every channel, whatever the escalation stage.
+		w.incidentAlert(rewardIncident(o, w.currentRound, msg), alert{
+			Type:         "reward_final_call",
+			Message:      msg,
+			Color:        0xFF0000,
+			Severity:     severityCritical,
+			Round:        w.currentRound,
+			Orchestrator: o.address,
+		})
+		o.finalCallSent = true
+	}
+}
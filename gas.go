@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// gasHistorySize is the number of past reward transactions used to compute the gas norm.
+const gasHistorySize = 10
+
+// gasMinHistory is the number of observations needed before anomalies are reported.
+const gasMinHistory = 3
+
+// gasTracker keeps a rolling history of gas used by reward transactions.
+type gasTracker struct {
+	history []uint64
+}
+
+// observe records the gas used by a reward transaction and reports the historical mean
+// and whether the observation deviates from it by more than the given relative threshold.
+func (t *gasTracker) observe(gasUsed uint64, threshold float64) (float64, bool) {
+	var mean float64
+	anomalous := false
+	if len(t.history) >= gasMinHistory {
+		var total uint64
+		for _, g := range t.history {
+			total += g
+		}
+		mean = float64(total) / float64(len(t.history))
+		if threshold > 0 && mean > 0 && math.Abs(float64(gasUsed)-mean)/mean > threshold {
+			anomalous = true
+		}
+	}
+	t.history = append(t.history, gasUsed)
+	if len(t.history) > gasHistorySize {
+		t.history = t.history[len(t.history)-gasHistorySize:]
+	}
+	return mean, anomalous
+}
+
+// parseGasPrice parses a gas price in wei, or in gwei with a "gwei" suffix. Empty means no limit.
+func parseGasPrice(raw string) (*big.Int, error) {
+	raw = strings.TrimSpace(strings.ToLower(raw))
+	if raw == "" {
+		return nil, nil
+	}
+	if gwei, ok := strings.CutSuffix(raw, "gwei"); ok {
+		value, ok := new(big.Float).SetString(strings.TrimSpace(gwei))
+		if !ok || value.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid gas price %q", raw)
+		}
+		wei, _ := value.Mul(value, big.NewFloat(1e9)).Int(nil)
+		return wei, nil
+	}
+	wei, ok := new(big.Int).SetString(raw, 10)
+	if !ok || wei.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid gas price %q, expected wei or a gwei amount like 0.1gwei", raw)
+	}
+	return wei, nil
+}
+
+// gasPriceDiagnosis reports when the current gas price exceeds the orchestrator's maximum gas
+// price, which makes go-livepeer skip the reward call.
+func (w *watcher) gasPriceDiagnosis() string {
+	if w.opts.maxGasPrice == nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	price, err := w.client.SuggestGasPrice(ctx)
+	if err != nil {
+		w.log.Printf("failed to fetch gas price: %v", err)
+		return ""
+	}
+	if price.Cmp(w.opts.maxGasPrice) <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(tr(" Current gas price %s gwei exceeds the max gas price of %s gwei, so reward was likely skipped due to the gas ceiling."),
+		formatUnits(price, 9, 4), formatUnits(w.opts.maxGasPrice, 9, 4))
+}
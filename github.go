@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// goLivepeerReleasesURL is the GitHub API endpoint listing go-livepeer releases.
+const goLivepeerReleasesURL = "https://api.github.com/repos/livepeer/go-livepeer/releases"
+
+// githubRelease contains the release fields we use from the GitHub API.
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+var githubHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// fetchGoLivepeerReleases returns the published, non-prerelease go-livepeer releases, newest first.
+func fetchGoLivepeerReleases() ([]githubRelease, error) {
+	req, err := http.NewRequest(http.MethodGet, goLivepeerReleasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := githubHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub releases request failed: HTTP %d", resp.StatusCode)
+	}
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub releases: %v", err)
+	}
+	out := releases[:0]
+	for _, r := range releases {
+		if !r.Draft && !r.Prerelease {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// isSecurityRelease reports whether a release is marked as containing security fixes.
+func (r githubRelease) isSecurityRelease() bool {
+	text := strings.ToLower(r.Name + " " + r.Body)
+	return strings.Contains(text, "security") || strings.Contains(text, "vulnerability")
+}
+
+// parseVersion parses a "v1.2.3"-style version (ignoring any suffix) into its numeric parts.
+func parseVersion(raw string) ([3]int, bool) {
+	var v [3]int
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if i := strings.IndexAny(raw, "-+ "); i >= 0 {
+		raw = raw[:i]
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// compareVersions returns -1, 0 or 1 when a is older than, equal to or newer than b.
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
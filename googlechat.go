@@ -0,0 +1,35 @@
+package main
+
+import "fmt"
+
+// sendGoogleChatAlert posts an alert to a Google Chat space webhook as a card.
+func sendGoogleChatAlert(webhookURL, message string, color int, severity string, brand branding) error {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	widgets := []map[string]interface{}{
+		{"textParagraph": map[string]string{
+			"text": fmt.Sprintf(`<font color="#%06X"><b>%s</b></font>`, color, severity),
+		}},
+		{"textParagraph": map[string]string{"text": markdownBodyToHTML(message)}},
+	}
+	if brand.Footer != "" {
+		widgets = append(widgets, map[string]interface{}{
+			"textParagraph": map[string]string{"text": "<i>" + markdownBodyToHTML(brand.Footer) + "</i>"},
+		})
+	}
+	payload := map[string]interface{}{
+		"cardsV2": []map[string]interface{}{{
+			"cardId": "reward-watcher-alert",
+			"card": map[string]interface{}{
+				"header":   map[string]string{"title": title},
+				"sections": []map[string]interface{}{{"widgets": widgets}},
+			},
+		}},
+	}
+	if err := postJSON(webhookURL, payload); err != nil {
+		return fmt.Errorf("google chat webhook: %v", err)
+	}
+	return nil
+}
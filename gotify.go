@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gotifyConfig configures the Gotify alert channel.
+type gotifyConfig struct {
+	URL      string `json:"url"`
+	AppToken string `json:"appToken"`
+}
+
+func (c gotifyConfig) complete() bool {
+	return c.URL != "" && c.AppToken != ""
+}
+
+// gotifyPriorities maps alert severities to Gotify priorities. Clients notify loudly from 8 up.
+var gotifyPriorities = map[string]int{
+	"critical": 8,
+	"warning":  5,
+	"info":     2,
+}
+
+// sendGotifyAlert pushes an alert to a Gotify server, rendered as markdown.
+func sendGotifyAlert(cfg gotifyConfig, title, message, severity string) error {
+	extras := map[string]interface{}{
+		"client::display": map[string]string{"contentType": "text/markdown"},
+	}
+	if link := markdownLinkRe.FindStringSubmatch(message); link != nil {
+		extras["client::notification"] = map[string]interface{}{"click": map[string]string{"url": link[2]}}
+	}
+	payload := map[string]interface{}{
+		"title":    title,
+		"message":  message,
+		"priority": gotifyPriorities[severity],
+		"extras":   extras,
+	}
+	headers := map[string]string{"X-Gotify-Key": cfg.AppToken}
+	if err := postJSONWithHeaders(strings.TrimRight(cfg.URL, "/")+"/message", headers, payload); err != nil {
+		return fmt.Errorf("gotify server: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// headLagState tracks how far the processed blocks trail the chain head.
+type headLagState struct {
+	lastProcessed uint64
+	lagging       bool
+}
+
+// processed records a block seen through the watcher's subscriptions.
+func (s *headLagState) processed(block uint64) {
+	if block > s.lastProcessed {
+		s.lastProcessed = block
+	}
+}
+
+// referenceRPC returns the endpoint the chain head is compared against: the configured
+// reference RPC, or another endpoint from the RPC list.
+func (w *watcher) referenceRPC() string {
+	if w.net.ReferenceRPC != "" {
+		return w.net.ReferenceRPC
+	}
+	for _, rpc := range w.net.RPCs {
+		if rpc != w.rpcURL {
+			return rpc
+		}
+	}
+	return ""
+}
+
+// fetchBlockNumber returns the latest block number of an RPC endpoint.
+func fetchBlockNumber(rpcURL string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	rc, err := rpc.DialOptions(ctx, rpcURL, rpcDialOptions(rpcURL)...)
+	if err != nil {
+		return 0, err
+	}
+	client := ethclient.NewClient(rc)
+	defer client.Close()
+	return client.BlockNumber(ctx)
+}
+
+// checkHeadLag alerts when the last processed block falls more than --head-lag-threshold
+// blocks behind the head reported by a reference RPC, i.e. the subscription is alive but
+// blocks stopped flowing.
+func (w *watcher) checkHeadLag() {
+	reference := w.referenceRPC()
+	if reference == "" || w.headLag.lastProcessed == 0 {
+		return
+	}
+	head, err := fetchBlockNumber(reference)
+	if err != nil {
+		w.log.Printf("failed to fetch chain head from reference RPC %s: %v", maskRPCURL(reference), err)
+		return
+	}
+	var lag uint64
+	if head > w.headLag.lastProcessed {
+		lag = head - w.headLag.lastProcessed
+	}
+	lagging := lag > w.opts.headLagThreshold
+	if lagging == w.headLag.lagging {
+		return
+	}
+	w.headLag.lagging = lagging
+	if lagging {
+		msg := fmt.Sprintf(
+			tr("⚠️ Last processed block %d is %d blocks behind the chain head %d reported by %s. The subscription may have stopped delivering events."),
+			w.headLag.lastProcessed, lag, head, maskRPCURL(reference))
+		w.log.Println(msg)
+		w.alert("head_lag", msg, 0xFFA500)
+		return
+	}
+	msg := fmt.Sprintf(tr("✅ Processed blocks caught up with the chain head (block %d)."), w.headLag.lastProcessed)
+	w.log.Println(msg)
+	w.alert("head_lag_resolved", msg, 0x00FF00)
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embed the time zone database, which minimal images such as Alpine lack, for --tz.
+	_ "time/tzdata"
+)
+
+// language is the language of alert texts, set with --lang.
+var language = "en"
+
+// languages lists the supported alert languages.
+var languages = []string{"en", "es", "de", "zh"}
+
+// setLanguage selects the language of alert texts.
+func setLanguage(lang string) error {
+	for _, l := range languages {
+		if l == lang {
+			language = lang
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported language %q, expected one of %s", lang, strings.Join(languages, ", "))
+}
+
+// tr translates an English alert format string into the selected language. Formats without a
+// translation are returned unchanged. Translations may reorder arguments with explicit
+// argument indexes such as %[2]d.
+func tr(format string) string {
+	if t := catalogs[language][format]; t != "" {
+		return t
+	}
+	return format
+}
+
+// durationUnits are the hour, minute and second units of formatted durations.
+var durationUnits = map[string][3]string{
+	"es": {" h", " min", " s"},
+	"de": {" Std.", " Min.", " Sek."},
+	"zh": {"小时", "分钟", "秒"},
+}
+
+// formatDuration formats a duration in the selected language.
+func formatDuration(d time.Duration) string {
+	units, ok := durationUnits[language]
+	if !ok {
+		return d.String()
+	}
+	d = d.Round(time.Second)
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	var parts []string
+	for i, v := range []int{h, m, s} {
+		if v != 0 || (i == 2 && len(parts) == 0) {
+			parts = append(parts, fmt.Sprintf("%d%s", v, units[i]))
+		}
+	}
+	sep := " "
+	if language == "zh" {
+		sep = ""
+	}
+	return strings.Join(parts, sep)
+}
+
+// location is the time zone of the times in alerts and of quiet hours, set with --tz.
+var location = time.Local
+
+// setTimezone selects the time zone of alert times by IANA name, e.g. "Europe/Amsterdam".
+func setTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	location = loc
+	return nil
+}
+
+// dateLayouts are the layouts of formatted dates per language.
+var dateLayouts = map[string]string{
+	"en": "2006-01-02 15:04 MST",
+	"es": "02/01/2006 15:04 MST",
+	"de": "02.01.2006 15:04 MST",
+	"zh": "2006年01月02日 15:04 MST",
+}
+
+// formatDate formats a time in the alert time zone and the selected language.
+func formatDate(t time.Time) string {
+	return t.In(location).Format(dateLayouts[language])
+}
+
+// alertTimes describes when an alert was sent and, for round alerts, when the round started.
+func alertTimes(a alert, now time.Time) string {
+	line := fmt.Sprintf(tr("\n🕒 %s"), formatDate(now))
+	if a.Round != 0 && !a.RoundStart.IsZero() {
+		line += fmt.Sprintf(tr(" · round %d started %s (%s ago)"), a.Round, formatDate(a.RoundStart), formatDuration(now.Sub(a.RoundStart).Round(time.Minute)))
+	}
+	return line
+}
+
+// catalogs holds the translations of the alert texts, keyed by language and English format string.
+var catalogs = map[string]map[string]string{
+	"es": {
+		"❌ Failed to connect to any RPC after %s, giving up and shutting down reward watcher!":                                         "❌ No se pudo conectar a ningún RPC tras %s, el reward watcher se detiene.",
+		"🟢 Livepeer Reward watcher monitoring orchestrator %s on %s.":                                                                  "🟢 Livepeer Reward watcher vigilando el orquestador %s en %s.",
+		"✅ RPC connection restored to %s, resuming monitoring.":                                                                        "✅ Conexión RPC restablecida con %s, se reanuda la vigilancia.",
+		"⚠️ %s subscription error: %v":                                                                                                 "⚠️ Error en la suscripción %s: %v",
+		"✅ Reward called for %s in round %d at block %d, [tx %s](https://arbiscan.io/tx/%s).":                                          "✅ Reward llamado para %s en la ronda %d en el bloque %d, [tx %s](https://arbiscan.io/tx/%s).",
+		" Treasury contribution: %s LPT.":                                                                                              " Contribución a la tesorería: %s LPT.",
+		"⛽ Reward call for %s in round %d used %d gas, %.0f%% off the recent average of %.0f gas, [tx %s](https://arbiscan.io/tx/%s).": "⛽ La llamada a reward de %s en la ronda %d usó %d de gas, un %.0f%% distinto de la media reciente de %.0f, [tx %s](https://arbiscan.io/tx/%s).",
+		"🔄 New round %d started.":                                                                                                      "🔄 Comenzó la ronda %d.",
+		"⚠️ No Reward events observed across the whole network for %s. This likely indicates an RPC problem or a protocol incident.":   "⚠️ No se observaron eventos Reward en toda la red durante %s. Probablemente indica un problema del RPC o un incidente del protocolo.",
+		"⚠️ No NewRound event observed for %s. This likely indicates an RPC problem or a protocol incident.":                           "⚠️ No se observó ningún evento NewRound durante %s. Probablemente indica un problema del RPC o un incidente del protocolo.",
+		"❌ No reward called for %s in round %d after %s.":                                                                              "❌ %[1]s no llamó a reward en la ronda %[2]d tras %[3]s.",
+		" Escalated to %s.": " Escalado a %s.",
+		"✅ Pending transactions of reward caller %s have been mined.":                                                                                           "✅ Las transacciones pendientes de la cuenta de reward %s se han minado.",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %d transaction(s) pending for over %s (nonce %d). They may be stuck and block reward calls.": "⚠️ La cuenta de reward [%s](https://arbiscan.io/address/%s) tiene %d transacción(es) pendiente(s) desde hace más de %s (nonce %d). Pueden estar atascadas y bloquear las llamadas a reward.",
+		"🛑 Orchestrator %s left the active set in round %d.":                                                                                                    "🛑 El orquestador %s salió del conjunto activo en la ronda %d.",
+		"⚠️ Orchestrator %s is scheduled to leave the active set in round %d.":                                                                                  "⚠️ El orquestador %s saldrá del conjunto activo en la ronda %d.",
+		" That is %d round(s) from now.":                                                                                                                    " Faltan %d ronda(s).",
+		"⏳ Orchestrator %s leaves the active set in %d round(s), in round %d.":                                                                              "⏳ El orquestador %s sale del conjunto activo en %d ronda(s), en la ronda %d.",
+		"✅ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) claimed earnings up to round %d.":                                           "✅ El delegador [%s](https://explorer.livepeer.org/accounts/%s/delegating) reclamó sus ganancias hasta la ronda %d.",
+		"⚠️ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) last claimed earnings in round %d, %d rounds behind the current round %d.": "⚠️ El delegador [%s](https://explorer.livepeer.org/accounts/%s/delegating) reclamó sus ganancias por última vez en la ronda %d, %d rondas por detrás de la ronda actual %d.",
+		"⚠️ Last processed block %d is %d blocks behind the chain head %d reported by %s. The subscription may have stopped delivering events.":             "⚠️ El último bloque procesado %d está %d bloques por detrás de la cabeza de la cadena %d según %s. Puede que la suscripción haya dejado de entregar eventos.",
+		"✅ Processed blocks caught up with the chain head (block %d).":                                                                                      "✅ Los bloques procesados alcanzaron la cabeza de la cadena (bloque %d).",
+		"❌ Orchestrator node runs go-livepeer %s and misses security release(s) %s. Latest is [%s](%s).":                                                    "❌ El nodo del orquestador ejecuta go-livepeer %s y le faltan las versiones de seguridad %s. La última es [%s](%s).",
+		"⚠️ Orchestrator node runs go-livepeer %s, %d release(s) behind the latest [%s](%s).":                                                               "⚠️ El nodo del orquestador ejecuta go-livepeer %s, %d versión(es) por detrás de la última [%s](%s).",
+		"✅ ServiceURI %s of %s is reachable again.":                                                                                                         "✅ La ServiceURI %s de %s vuelve a estar accesible.",
+		"⚠️ TLS certificate of ServiceURI %s of %s expires on %s (in %s).":                                                                                  "⚠️ El certificado TLS de la ServiceURI %s de %s caduca el %s (en %s).",
+		"❌ TLS certificate of ServiceURI %s of %s expired on %s.":                                                                                           "❌ El certificado TLS de la ServiceURI %s de %s caducó el %s.",
+		"❌ ServiceURI %s of %s is unreachable: %v. Broadcasters can't send jobs to it.":                                                                     "❌ La ServiceURI %s de %s no es accesible: %v. Los broadcasters no pueden enviarle trabajos.",
+		"🔁 Contract ABI refreshed after an implementation change: %s.":                                                                                      "🔁 ABI del contrato actualizada tras un cambio de implementación: %s.",
+		"🚀 New go-livepeer release [%s](%s) published.":                                                                                                     "🚀 Publicada la nueva versión de go-livepeer [%s](%s).",
+		"📊 Round %d summary for %s:":                                                                                                                        "📊 Resumen de la ronda %d de %s:",
+		" %s LPT minted%s.":                                                                                                                                 " %s LPT acuñados%s.",
+		" Simulating reward() succeeded, so the call would go through now; check that the reward caller is running and funded.":                             " La simulación de reward() tuvo éxito, así que la llamada pasaría ahora; comprueba que el emisor de reward esté en marcha y tenga fondos.",
+		" Simulating reward() reverts with %q. %s":                                                                                                          " La simulación de reward() revierte con %q. %s",
+		" Simulating reward() fails: %s.":                                                                                                                   " La simulación de reward() falla: %s.",
+		"The round has not been initialized yet; anyone can call initializeRound() on the RoundsManager.":                                                   "La ronda aún no se ha inicializado; cualquiera puede llamar a initializeRound() en el RoundsManager.",
+		"Reward was already called this round, but the watcher did not see the event; check the RPC.":                                                       "Reward ya se llamó en esta ronda, pero el vigilante no vio el evento; revisa el RPC.",
+		"The orchestrator is not in the active set this round, so it cannot call reward.":                                                                   "El orquestador no está en el conjunto activo esta ronda, así que no puede llamar a reward.",
+		"The protocol is paused.":                                  "El protocolo está en pausa.",
+		"✅ Reward events are being observed on the network again.": "✅ Se vuelven a observar eventos de reward en la red.",
+		"🔇 Alerts muted until %s.":                                 "🔇 Alertas silenciadas hasta %s.",
+		"🔔 Alerts unmuted.":                                        "🔔 Alertas reactivadas.",
+		"Usage: mute <duration>, e.g. mute 6h":                     "Uso: mute <duración>, p. ej. mute 6h",
+		"Unknown command. Available commands: reward-status, round, lastreward, mute <duration>, unmute.": "Comando desconocido. Comandos disponibles: reward-status, round, lastreward, mute <duración>, unmute.",
+		"🔇 Alerts are muted until %s.":                                   "🔇 Las alertas están silenciadas hasta %s.",
+		"↩️ Switched back from the backup RPC %s to the primary RPC %s.": "↩️ Se volvió del RPC de respaldo %s al RPC principal %s.",
+		"⚠️ RPC endpoints disagree about the %s event of [tx %s](https://arbiscan.io/tx/%s) in block %d: %s report it differently than %s.":                                                  "⚠️ Los endpoints RPC no coinciden sobre el evento %s de [tx %s](https://arbiscan.io/tx/%s) en el bloque %d: %s lo reportan distinto que %s.",
+		"⚠️ Only %d of the %d required RPC endpoints confirmed the %s event of [tx %s](https://arbiscan.io/tx/%s) within %s, so it is ignored.":                                              "⚠️ Solo %d de los %d endpoints RPC requeridos confirmaron el evento %s de [tx %s](https://arbiscan.io/tx/%s) en %s, así que se ignora.",
+		"❌ RPC %s serves chain ID %s instead of %d, so it is not used. Check that it is an endpoint of %s.":                                                                                  "❌ El RPC %s sirve el chain ID %s en lugar de %d, así que no se usa. Comprueba que sea un endpoint de %s.",
+		"⚠️ The reward call of %s in round %d ([tx %s](https://arbiscan.io/tx/%s)) was removed by a chain reorg. Reward is no longer called and the missed-reward warning is re-armed.":      "⚠️ La llamada a reward de %s en la ronda %d ([tx %s](https://arbiscan.io/tx/%s)) fue eliminada por una reorganización de la cadena. Reward ya no cuenta como llamado y la advertencia de reward no llamado vuelve a estar activa.",
+		"⏳ Reward for %s in round %d is not confirmed yet, but reward transaction [%s](https://arbiscan.io/tx/%s) has been pending in the mempool for %s.":                                   "⏳ El reward de %s en la ronda %d aún no está confirmado, pero la transacción de reward [%s](https://arbiscan.io/tx/%s) lleva %s pendiente en la mempool.",
+		"❌ Reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d has been pending in the mempool for %s. It may be stuck, e.g. with a gas price below the current base fee.": "❌ La transacción de reward [%s](https://arbiscan.io/tx/%s) de %s en la ronda %d lleva %s pendiente en la mempool. Puede estar atascada, p. ej. con un precio de gas inferior a la tarifa base actual.",
+		"❌ Transaction [%s](https://arbiscan.io/tx/%s) from reward caller %s calling %s() on the BondingManager reverted: %s.":                                                               "❌ La transacción [%s](https://arbiscan.io/tx/%s) de la cuenta de reward %s que llama a %s() en el BondingManager se revirtió: %s.",
+		"out of gas":     "sin gas",
+		"unknown reason": "motivo desconocido",
+		"❌ Auto-reward attempt %d/%d for %s in round %d failed: %v":                                                                                                      "❌ El intento de auto-reward %d/%d para %s en la ronda %d falló: %v",
+		"🤖 Reward still missing for %s in round %d, so the watcher submitted reward() in transaction [%s](https://arbiscan.io/tx/%s) (attempt %d/%d).":                   "🤖 %s aún no ha llamado a reward en la ronda %d, así que el watcher envió reward() en la transacción [%s](https://arbiscan.io/tx/%s) (intento %d/%d).",
+		"❌ The auto-reward transaction for %s in round %d (attempt %d/%d) was not mined: %v":                                                                             "❌ La transacción de auto-reward para %s en la ronda %d (intento %d/%d) no se minó: %v",
+		"❌ The auto-reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d reverted (attempt %d/%d).":                                                     "❌ La transacción de auto-reward [%s](https://arbiscan.io/tx/%s) para %s en la ronda %d se revirtió (intento %d/%d).",
+		"✅ The watcher called reward for %s in round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                 "✅ El watcher llamó a reward para %s en la ronda %d en la transacción [%s](https://arbiscan.io/tx/%s).",
+		"❌ Failed to submit initializeRound() for round %d: %v":                                                                                                          "❌ No se pudo enviar initializeRound() para la ronda %d: %v",
+		"❌ The initializeRound() transaction for round %d was not mined: %v":                                                                                             "❌ La transacción initializeRound() de la ronda %d no se minó: %v",
+		"❌ The initializeRound() transaction [%s](https://arbiscan.io/tx/%s) for round %d reverted.":                                                                     "❌ La transacción initializeRound() [%s](https://arbiscan.io/tx/%s) de la ronda %d se revirtió.",
+		"✅ The watcher initialized round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                             "✅ El watcher inicializó la ronda %d en la transacción [%s](https://arbiscan.io/tx/%s).",
+		"✅ Round %d has been initialized, reward can be called again.":                                                                                                   "✅ La ronda %d se ha inicializado, ya se puede volver a llamar a reward.",
+		"⚠️ Round %d started %s ago on L1 but has not been initialized yet, so no orchestrator can call reward. Anyone can call initializeRound() on the RoundsManager.": "⚠️ La ronda %d comenzó en L1 hace %s pero aún no se ha inicializado, así que ningún orquestador puede llamar a reward. Cualquiera puede llamar a initializeRound() en el RoundsManager.",
+		"🚨 Last chance: %s has still not called reward in round %d and the round ends in approximately %s.":                                                              "🚨 Última oportunidad: %s aún no ha llamado a reward en la ronda %d y la ronda termina en aproximadamente %s.",
+		" Approximately %s remaining to call reward.":                                                                                                                    " Quedan aproximadamente %s para llamar a reward.",
+		"⚠️ No reward called for %s in round %d yet after %s.":                                                                                                           "⚠️ %[1]s aún no llamó a reward en la ronda %[2]d tras %[3]s.",
+		"⏳ No reward called for %s in round %d yet after %s.":                                                                                                            "⏳ %[1]s aún no llamó a reward en la ronda %[2]d tras %[3]s.",
+		"%.0f%% of the round": "el %.0f%% de la ronda",
+		"✅ Reward caller [%s](https://arbiscan.io/address/%s) was topped up to %s ETH.":                                                                 "✅ La cuenta de reward [%s](https://arbiscan.io/address/%s) se recargó hasta %s ETH.",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %s ETH left, below the minimum of %g ETH. Top it up so it can pay for reward calls.": "⚠️ A la cuenta de reward [%s](https://arbiscan.io/address/%s) le quedan %s ETH, por debajo del mínimo de %g ETH. Recárgala para que pueda pagar las llamadas a reward.",
+		" Current gas price %s gwei exceeds the max gas price of %s gwei, so reward was likely skipped due to the gas ceiling.":                         " El precio del gas actual de %s gwei supera el precio máximo de %s gwei, así que reward probablemente se omitió por el límite de gas.",
+		" Gas used: %d, fee: %s ETH%s.":                                 " Gas usado: %d, comisión: %s ETH%s.",
+		", %s ETH in transaction fees":                                  ", %s ETH en comisiones de transacción",
+		" %s LPT minted by all watched orchestrators this round.":       " %s LPT acuñados por todos los orquestadores vigilados en esta ronda.",
+		"%s digest: %d alert(s)":                                        "%s: resumen de %d alerta(s)",
+		"\n✅ Reward called":                                             "\n✅ Reward llamado",
+		" %s after the round started":                                   " %s después del inicio de la ronda",
+		", %s LPT minted":                                               ", %s LPT acuñados",
+		"\n❌ Reward was not called.":                                    "\n❌ No se llamó a reward.",
+		"\n💰 %s ETH in fees earned from %d redeemed winning ticket(s).": "\n💰 %s ETH en comisiones de %d ticket(s) ganador(es) canjeado(s).",
+		"\n🏛 Treasury contribution: %s LPT this round, %s LPT since the watcher started.": "\n🏛 Contribución a la tesorería: %s LPT esta ronda, %s LPT desde que arrancó el watcher.",
+		"\n📈 Stake: %s%s LPT (total %s LPT).":                                             "\n📈 Stake: %s%s LPT (total %s LPT).",
+		" · round %d started %s (%s ago)":                                                 " · la ronda %d empezó el %s (hace %s)",
+	},
+	"de": {
+		"❌ Failed to connect to any RPC after %s, giving up and shutting down reward watcher!":                                         "❌ Nach %s keine Verbindung zu einem RPC möglich, der Reward Watcher wird beendet!",
+		"🟢 Livepeer Reward watcher monitoring orchestrator %s on %s.":                                                                  "🟢 Livepeer Reward Watcher überwacht Orchestrator %s auf %s.",
+		"✅ RPC connection restored to %s, resuming monitoring.":                                                                        "✅ RPC-Verbindung zu %s wiederhergestellt, Überwachung läuft weiter.",
+		"⚠️ %s subscription error: %v":                                                                                                 "⚠️ Fehler im Abonnement %s: %v",
+		"✅ Reward called for %s in round %d at block %d, [tx %s](https://arbiscan.io/tx/%s).":                                          "✅ Reward für %s in Runde %d in Block %d aufgerufen, [Tx %s](https://arbiscan.io/tx/%s).",
+		" Treasury contribution: %s LPT.":                                                                                              " Treasury-Beitrag: %s LPT.",
+		"⛽ Reward call for %s in round %d used %d gas, %.0f%% off the recent average of %.0f gas, [tx %s](https://arbiscan.io/tx/%s).": "⛽ Der Reward-Aufruf für %s in Runde %d verbrauchte %d Gas, %.0f%% abweichend vom jüngsten Durchschnitt von %.0f Gas, [Tx %s](https://arbiscan.io/tx/%s).",
+		"🔄 New round %d started.":                                                                                                      "🔄 Neue Runde %d gestartet.",
+		"⚠️ No Reward events observed across the whole network for %s. This likely indicates an RPC problem or a protocol incident.":   "⚠️ Seit %s keine Reward-Events im gesamten Netzwerk beobachtet. Das deutet auf ein RPC-Problem oder einen Protokollvorfall hin.",
+		"⚠️ No NewRound event observed for %s. This likely indicates an RPC problem or a protocol incident.":                           "⚠️ Seit %s kein NewRound-Event beobachtet. Das deutet auf ein RPC-Problem oder einen Protokollvorfall hin.",
+		"❌ No reward called for %s in round %d after %s.":                                                                              "❌ Kein Reward-Aufruf für %s in Runde %d nach %s.",
+		" Escalated to %s.": " Eskaliert an %s.",
+		"✅ Pending transactions of reward caller %s have been mined.":                                                                                           "✅ Ausstehende Transaktionen des Reward-Callers %s wurden gemined.",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %d transaction(s) pending for over %s (nonce %d). They may be stuck and block reward calls.": "⚠️ Reward-Caller [%s](https://arbiscan.io/address/%s) hat seit über %[4]s %[3]d ausstehende Transaktion(en) (Nonce %[5]d). Sie könnten feststecken und Reward-Aufrufe blockieren.",
+		"🛑 Orchestrator %s left the active set in round %d.":                                                                                                    "🛑 Orchestrator %s hat das aktive Set in Runde %d verlassen.",
+		"⚠️ Orchestrator %s is scheduled to leave the active set in round %d.":                                                                                  "⚠️ Orchestrator %s verlässt das aktive Set planmäßig in Runde %d.",
+		" That is %d round(s) from now.":                                                                                                                    " Das ist in %d Runde(n).",
+		"⏳ Orchestrator %s leaves the active set in %d round(s), in round %d.":                                                                              "⏳ Orchestrator %s verlässt das aktive Set in %d Runde(n), in Runde %d.",
+		"✅ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) claimed earnings up to round %d.":                                           "✅ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) hat Erträge bis Runde %d beansprucht.",
+		"⚠️ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) last claimed earnings in round %d, %d rounds behind the current round %d.": "⚠️ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) hat zuletzt in Runde %d Erträge beansprucht, %d Runden hinter der aktuellen Runde %d.",
+		"⚠️ Last processed block %d is %d blocks behind the chain head %d reported by %s. The subscription may have stopped delivering events.":             "⚠️ Der zuletzt verarbeitete Block %d liegt %d Blöcke hinter dem von %[4]s gemeldeten Chain-Head %[3]d. Das Abonnement liefert möglicherweise keine Events mehr.",
+		"✅ Processed blocks caught up with the chain head (block %d).":                                                                                      "✅ Verarbeitete Blöcke haben den Chain-Head eingeholt (Block %d).",
+		"❌ Orchestrator node runs go-livepeer %s and misses security release(s) %s. Latest is [%s](%s).":                                                    "❌ Der Orchestrator-Node läuft mit go-livepeer %s, ihm fehlen die Sicherheitsreleases %s. Aktuell ist [%s](%s).",
+		"⚠️ Orchestrator node runs go-livepeer %s, %d release(s) behind the latest [%s](%s).":                                                               "⚠️ Der Orchestrator-Node läuft mit go-livepeer %s, %d Release(s) hinter dem aktuellen [%s](%s).",
+		"✅ ServiceURI %s of %s is reachable again.":                                                                                                         "✅ ServiceURI %s von %s ist wieder erreichbar.",
+		"⚠️ TLS certificate of ServiceURI %s of %s expires on %s (in %s).":                                                                                  "⚠️ Das TLS-Zertifikat der ServiceURI %s von %s läuft am %s ab (in %s).",
+		"❌ TLS certificate of ServiceURI %s of %s expired on %s.":                                                                                           "❌ Das TLS-Zertifikat der ServiceURI %s von %s ist am %s abgelaufen.",
+		"❌ ServiceURI %s of %s is unreachable: %v. Broadcasters can't send jobs to it.":                                                                     "❌ ServiceURI %s von %s ist nicht erreichbar: %v. Broadcaster können keine Jobs senden.",
+		"🔁 Contract ABI refreshed after an implementation change: %s.":                                                                                      "🔁 Vertrags-ABI nach einem Implementierungswechsel aktualisiert: %s.",
+		"🚀 New go-livepeer release [%s](%s) published.":                                                                                                     "🚀 Neues go-livepeer-Release [%s](%s) veröffentlicht.",
+		"📊 Round %d summary for %s:":                                                                                                                        "📊 Zusammenfassung von Runde %d für %s:",
+		" %s LPT minted%s.":                                                                                                                                 " %s LPT geprägt%s.",
+		" Simulating reward() succeeded, so the call would go through now; check that the reward caller is running and funded.":                             " Die Simulation von reward() war erfolgreich, der Aufruf würde jetzt durchgehen; prüfe, ob der Reward-Aufrufer läuft und genug Guthaben hat.",
+		" Simulating reward() reverts with %q. %s":                                                                                                          " Die Simulation von reward() bricht mit %q ab. %s",
+		" Simulating reward() fails: %s.":                                                                                                                   " Die Simulation von reward() schlägt fehl: %s.",
+		"The round has not been initialized yet; anyone can call initializeRound() on the RoundsManager.":                                                   "Die Runde wurde noch nicht initialisiert; jeder kann initializeRound() im RoundsManager aufrufen.",
+		"Reward was already called this round, but the watcher did not see the event; check the RPC.":                                                       "Reward wurde in dieser Runde bereits aufgerufen, aber der Watcher hat das Event nicht gesehen; prüfe den RPC.",
+		"The orchestrator is not in the active set this round, so it cannot call reward.":                                                                   "Der Orchestrator ist in dieser Runde nicht im aktiven Set und kann reward nicht aufrufen.",
+		"The protocol is paused.":                                  "Das Protokoll ist pausiert.",
+		"✅ Reward events are being observed on the network again.": "✅ Im Netzwerk werden wieder Reward-Ereignisse beobachtet.",
+		"🔇 Alerts muted until %s.":                                 "🔇 Warnungen stummgeschaltet bis %s.",
+		"🔔 Alerts unmuted.":                                        "🔔 Warnungen wieder aktiviert.",
+		"Usage: mute <duration>, e.g. mute 6h":                     "Verwendung: mute <Dauer>, z. B. mute 6h",
+		"Unknown command. Available commands: reward-status, round, lastreward, mute <duration>, unmute.": "Unbekannter Befehl. Verfügbare Befehle: reward-status, round, lastreward, mute <Dauer>, unmute.",
+		"🔇 Alerts are muted until %s.":                                   "🔇 Warnungen sind stummgeschaltet bis %s.",
+		"↩️ Switched back from the backup RPC %s to the primary RPC %s.": "↩️ Vom Backup-RPC %s zurück zum primären RPC %s gewechselt.",
+		"⚠️ RPC endpoints disagree about the %s event of [tx %s](https://arbiscan.io/tx/%s) in block %d: %s report it differently than %s.":                                                  "⚠️ RPC-Endpunkte sind sich über das %s-Event von [tx %s](https://arbiscan.io/tx/%s) in Block %d uneinig: %s melden es anders als %s.",
+		"⚠️ Only %d of the %d required RPC endpoints confirmed the %s event of [tx %s](https://arbiscan.io/tx/%s) within %s, so it is ignored.":                                              "⚠️ Nur %d der %d erforderlichen RPC-Endpunkte haben das %s-Event von [tx %s](https://arbiscan.io/tx/%s) innerhalb von %s bestätigt, daher wird es ignoriert.",
+		"❌ RPC %s serves chain ID %s instead of %d, so it is not used. Check that it is an endpoint of %s.":                                                                                  "❌ RPC %s liefert Chain-ID %s statt %d und wird daher nicht verwendet. Prüfe, ob es ein Endpunkt von %s ist.",
+		"⚠️ The reward call of %s in round %d ([tx %s](https://arbiscan.io/tx/%s)) was removed by a chain reorg. Reward is no longer called and the missed-reward warning is re-armed.":      "⚠️ Der Reward-Aufruf von %s in Runde %d ([tx %s](https://arbiscan.io/tx/%s)) wurde durch eine Chain-Reorganisation entfernt. Reward gilt nicht mehr als aufgerufen und die Warnung für fehlende Rewards ist wieder aktiv.",
+		"⏳ Reward for %s in round %d is not confirmed yet, but reward transaction [%s](https://arbiscan.io/tx/%s) has been pending in the mempool for %s.":                                   "⏳ Reward für %s in Runde %d ist noch nicht bestätigt, aber die Reward-Transaktion [%s](https://arbiscan.io/tx/%s) wartet seit %s im Mempool.",
+		"❌ Reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d has been pending in the mempool for %s. It may be stuck, e.g. with a gas price below the current base fee.": "❌ Die Reward-Transaktion [%s](https://arbiscan.io/tx/%s) für %s in Runde %d wartet seit %s im Mempool. Sie könnte feststecken, z. B. mit einem Gaspreis unter der aktuellen Grundgebühr.",
+		"❌ Transaction [%s](https://arbiscan.io/tx/%s) from reward caller %s calling %s() on the BondingManager reverted: %s.":                                                               "❌ Transaktion [%s](https://arbiscan.io/tx/%s) des Reward-Callers %s mit Aufruf von %s() im BondingManager wurde rückgängig gemacht: %s.",
+		"out of gas":     "Gas aufgebraucht",
+		"unknown reason": "unbekannter Grund",
+		"❌ Auto-reward attempt %d/%d for %s in round %d failed: %v":                                                                                                      "❌ Auto-Reward-Versuch %d/%d für %s in Runde %d fehlgeschlagen: %v",
+		"🤖 Reward still missing for %s in round %d, so the watcher submitted reward() in transaction [%s](https://arbiscan.io/tx/%s) (attempt %d/%d).":                   "🤖 Reward für %s in Runde %d fehlt noch, daher hat der Watcher reward() in Transaktion [%s](https://arbiscan.io/tx/%s) gesendet (Versuch %d/%d).",
+		"❌ The auto-reward transaction for %s in round %d (attempt %d/%d) was not mined: %v":                                                                             "❌ Die Auto-Reward-Transaktion für %s in Runde %d (Versuch %d/%d) wurde nicht gemined: %v",
+		"❌ The auto-reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d reverted (attempt %d/%d).":                                                     "❌ Die Auto-Reward-Transaktion [%s](https://arbiscan.io/tx/%s) für %s in Runde %d wurde rückgängig gemacht (Versuch %d/%d).",
+		"✅ The watcher called reward for %s in round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                 "✅ Der Watcher hat Reward für %s in Runde %d in Transaktion [%s](https://arbiscan.io/tx/%s) aufgerufen.",
+		"❌ Failed to submit initializeRound() for round %d: %v":                                                                                                          "❌ initializeRound() für Runde %d konnte nicht gesendet werden: %v",
+		"❌ The initializeRound() transaction for round %d was not mined: %v":                                                                                             "❌ Die initializeRound()-Transaktion für Runde %d wurde nicht gemined: %v",
+		"❌ The initializeRound() transaction [%s](https://arbiscan.io/tx/%s) for round %d reverted.":                                                                     "❌ Die initializeRound()-Transaktion [%s](https://arbiscan.io/tx/%s) für Runde %d wurde rückgängig gemacht.",
+		"✅ The watcher initialized round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                             "✅ Der Watcher hat Runde %d in Transaktion [%s](https://arbiscan.io/tx/%s) initialisiert.",
+		"✅ Round %d has been initialized, reward can be called again.":                                                                                                   "✅ Runde %d wurde initialisiert, Reward kann wieder aufgerufen werden.",
+		"⚠️ Round %d started %s ago on L1 but has not been initialized yet, so no orchestrator can call reward. Anyone can call initializeRound() on the RoundsManager.": "⚠️ Runde %d hat vor %s auf L1 begonnen, wurde aber noch nicht initialisiert, daher kann kein Orchestrator Reward aufrufen. Jeder kann initializeRound() im RoundsManager aufrufen.",
+		"🚨 Last chance: %s has still not called reward in round %d and the round ends in approximately %s.":                                                              "🚨 Letzte Chance: %s hat in Runde %d noch immer kein Reward aufgerufen und die Runde endet in etwa %s.",
+		" Approximately %s remaining to call reward.":                                                                                                                    " Noch etwa %s Zeit, um Reward aufzurufen.",
+		"⚠️ No reward called for %s in round %d yet after %s.":                                                                                                           "⚠️ Noch kein Reward-Aufruf für %s in Runde %d nach %s.",
+		"⏳ No reward called for %s in round %d yet after %s.":                                                                                                            "⏳ Noch kein Reward-Aufruf für %s in Runde %d nach %s.",
+		"%.0f%% of the round": "%.0f%% der Runde",
+		"✅ Reward caller [%s](https://arbiscan.io/address/%s) was topped up to %s ETH.":                                                                 "✅ Der Reward-Caller [%s](https://arbiscan.io/address/%s) wurde auf %s ETH aufgeladen.",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %s ETH left, below the minimum of %g ETH. Top it up so it can pay for reward calls.": "⚠️ Der Reward-Caller [%s](https://arbiscan.io/address/%s) hat nur noch %s ETH, weniger als das Minimum von %g ETH. Lade ihn auf, damit er Reward-Aufrufe bezahlen kann.",
+		" Current gas price %s gwei exceeds the max gas price of %s gwei, so reward was likely skipped due to the gas ceiling.":                         " Der aktuelle Gaspreis von %s gwei liegt über dem maximalen Gaspreis von %s gwei, reward wurde daher wahrscheinlich wegen der Gasgrenze übersprungen.",
+		" Gas used: %d, fee: %s ETH%s.":                                 " Verbrauchtes Gas: %d, Gebühr: %s ETH%s.",
+		", %s ETH in transaction fees":                                  ", %s ETH an Transaktionsgebühren",
+		" %s LPT minted by all watched orchestrators this round.":       " %s LPT in dieser Runde von allen überwachten Orchestratoren geprägt.",
+		"%s digest: %d alert(s)":                                        "%s: Sammelbericht mit %d Alarm(en)",
+		"\n✅ Reward called":                                             "\n✅ Reward aufgerufen",
+		" %s after the round started":                                   " %s nach Rundenbeginn",
+		", %s LPT minted":                                               ", %s LPT gemintet",
+		"\n❌ Reward was not called.":                                    "\n❌ Reward wurde nicht aufgerufen.",
+		"\n💰 %s ETH in fees earned from %d redeemed winning ticket(s).": "\n💰 %s ETH Gebühren aus %d eingelösten Gewinntickets.",
+		"\n🏛 Treasury contribution: %s LPT this round, %s LPT since the watcher started.": "\n🏛 Treasury-Beitrag: %s LPT in dieser Runde, %s LPT seit dem Start des Watchers.",
+		"\n📈 Stake: %s%s LPT (total %s LPT).":                                             "\n📈 Stake: %s%s LPT (gesamt %s LPT).",
+		" · round %d started %s (%s ago)":                                                 " · Runde %d begann am %s (vor %s)",
+	},
+	"zh": {
+		"❌ Failed to connect to any RPC after %s, giving up and shutting down reward watcher!":                                         "❌ %s 内无法连接任何 RPC，reward watcher 即将退出！",
+		"🟢 Livepeer Reward watcher monitoring orchestrator %s on %s.":                                                                  "🟢 Livepeer Reward watcher 正在 %[2]s 上监控编排器 %[1]s。",
+		"✅ RPC connection restored to %s, resuming monitoring.":                                                                        "✅ 已恢复与 %s 的 RPC 连接，继续监控。",
+		"⚠️ %s subscription error: %v":                                                                                                 "⚠️ %s 订阅出错：%v",
+		"✅ Reward called for %s in round %d at block %d, [tx %s](https://arbiscan.io/tx/%s).":                                          "✅ %s 已在第 %d 轮（区块 %d）调用 reward，[交易 %s](https://arbiscan.io/tx/%s)。",
+		" Treasury contribution: %s LPT.":                                                                                              " 国库贡献：%s LPT。",
+		"⛽ Reward call for %s in round %d used %d gas, %.0f%% off the recent average of %.0f gas, [tx %s](https://arbiscan.io/tx/%s).": "⛽ %s 在第 %d 轮的 reward 调用消耗 %d gas，与近期平均值 %.0[5]f gas 相差 %.0[4]f%%，[交易 %[6]s](https://arbiscan.io/tx/%[7]s)。",
+		"🔄 New round %d started.":                                                                                                      "🔄 第 %d 轮已开始。",
+		"⚠️ No Reward events observed across the whole network for %s. This likely indicates an RPC problem or a protocol incident.":   "⚠️ 全网 %s 内未观察到 Reward 事件，可能是 RPC 问题或协议事故。",
+		"⚠️ No NewRound event observed for %s. This likely indicates an RPC problem or a protocol incident.":                           "⚠️ %s 内未观察到 NewRound 事件，可能是 RPC 问题或协议事故。",
+		"❌ No reward called for %s in round %d after %s.":                                                                              "❌ %[1]s 在第 %[2]d 轮开始 %[3]s 后仍未调用 reward。",
+		" Escalated to %s.": " 已升级至 %s。",
+		"✅ Pending transactions of reward caller %s have been mined.":                                                                                           "✅ reward 调用账户 %s 的待处理交易已被打包。",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %d transaction(s) pending for over %s (nonce %d). They may be stuck and block reward calls.": "⚠️ reward 调用账户 [%s](https://arbiscan.io/address/%s) 有 %d 笔交易已待处理超过 %s（nonce %d），可能已卡住并阻塞 reward 调用。",
+		"🛑 Orchestrator %s left the active set in round %d.":                                                                                                    "🛑 编排器 %s 已在第 %d 轮退出活跃集合。",
+		"⚠️ Orchestrator %s is scheduled to leave the active set in round %d.":                                                                                  "⚠️ 编排器 %s 计划在第 %d 轮退出活跃集合。",
+		" That is %d round(s) from now.":                                                                                                                    " 距今还有 %d 轮。",
+		"⏳ Orchestrator %s leaves the active set in %d round(s), in round %d.":                                                                              "⏳ 编排器 %s 将在 %d 轮后（第 %d 轮）退出活跃集合。",
+		"✅ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) claimed earnings up to round %d.":                                           "✅ 委托人 [%s](https://explorer.livepeer.org/accounts/%s/delegating) 已领取截至第 %d 轮的收益。",
+		"⚠️ Delegator [%s](https://explorer.livepeer.org/accounts/%s/delegating) last claimed earnings in round %d, %d rounds behind the current round %d.": "⚠️ 委托人 [%s](https://explorer.livepeer.org/accounts/%s/delegating) 最近一次领取收益是在第 %d 轮，落后当前第 %[5]d 轮 %[4]d 轮。",
+		"⚠️ Last processed block %d is %d blocks behind the chain head %d reported by %s. The subscription may have stopped delivering events.":             "⚠️ 最后处理的区块 %[1]d 落后 %[4]s 报告的链头 %[3]d 共 %[2]d 个区块，订阅可能已停止推送事件。",
+		"✅ Processed blocks caught up with the chain head (block %d).":                                                                                      "✅ 已处理区块追上链头（区块 %d）。",
+		"❌ Orchestrator node runs go-livepeer %s and misses security release(s) %s. Latest is [%s](%s).":                                                    "❌ 编排器节点运行 go-livepeer %s，缺少安全版本 %s。最新版本为 [%s](%s)。",
+		"⚠️ Orchestrator node runs go-livepeer %s, %d release(s) behind the latest [%s](%s).":                                                               "⚠️ 编排器节点运行 go-livepeer %s，落后最新版本 [%[3]s](%[4]s) %[2]d 个版本。",
+		"✅ ServiceURI %s of %s is reachable again.":                                                                                                         "✅ %[2]s 的 ServiceURI %[1]s 已恢复可访问。",
+		"⚠️ TLS certificate of ServiceURI %s of %s expires on %s (in %s).":                                                                                  "⚠️ %[2]s 的 ServiceURI %[1]s 的 TLS 证书将于 %[3]s 到期（还剩 %[4]s）。",
+		"❌ TLS certificate of ServiceURI %s of %s expired on %s.":                                                                                           "❌ %[2]s 的 ServiceURI %[1]s 的 TLS 证书已于 %[3]s 过期。",
+		"❌ ServiceURI %s of %s is unreachable: %v. Broadcasters can't send jobs to it.":                                                                     "❌ %[2]s 的 ServiceURI %[1]s 无法访问：%[3]v。广播者无法向其发送任务。",
+		"🔁 Contract ABI refreshed after an implementation change: %s.":                                                                                      "🔁 合约实现变更后已刷新 ABI：%s。",
+		"🚀 New go-livepeer release [%s](%s) published.":                                                                                                     "🚀 go-livepeer 新版本 [%s](%s) 已发布。",
+		"📊 Round %d summary for %s:":                                                                                                                        "📊 %[2]s 第 %[1]d 轮总结：",
+		" %s LPT minted%s.":                                                                                                                                 " 铸造了 %s LPT%s。",
+		" Simulating reward() succeeded, so the call would go through now; check that the reward caller is running and funded.":                             " 模拟 reward() 成功，现在调用可以通过；请检查 reward 调用方是否在运行且余额充足。",
+		" Simulating reward() reverts with %q. %s":                                                                                                          " 模拟 reward() 回滚：%q。%s",
+		" Simulating reward() fails: %s.":                                                                                                                   " 模拟 reward() 失败：%s。",
+		"The round has not been initialized yet; anyone can call initializeRound() on the RoundsManager.":                                                   "本轮尚未初始化；任何人都可以在 RoundsManager 上调用 initializeRound()。",
+		"Reward was already called this round, but the watcher did not see the event; check the RPC.":                                                       "本轮已调用 reward，但监控程序未收到该事件；请检查 RPC。",
+		"The orchestrator is not in the active set this round, so it cannot call reward.":                                                                   "编排器本轮不在活跃集合中，因此无法调用 reward。",
+		"The protocol is paused.":                                  "协议已暂停。",
+		"✅ Reward events are being observed on the network again.": "✅ 网络上再次观察到 reward 事件。",
+		"🔇 Alerts muted until %s.":                                 "🔇 告警已静音至 %s。",
+		"🔔 Alerts unmuted.":                                        "🔔 告警已取消静音。",
+		"Usage: mute <duration>, e.g. mute 6h":                     "用法：mute <时长>，例如 mute 6h",
+		"Unknown command. Available commands: reward-status, round, lastreward, mute <duration>, unmute.": "未知命令。可用命令：reward-status、round、lastreward、mute <时长>、unmute。",
+		"🔇 Alerts are muted until %s.":                                   "🔇 告警静音至 %s。",
+		"↩️ Switched back from the backup RPC %s to the primary RPC %s.": "↩️ 已从备用 RPC %s 切换回主 RPC %s。",
+		"⚠️ RPC endpoints disagree about the %s event of [tx %s](https://arbiscan.io/tx/%s) in block %d: %s report it differently than %s.":                                                  "⚠️ RPC 端点对区块 %[4]d 中 [tx %[2]s](https://arbiscan.io/tx/%[3]s) 的 %[1]s 事件不一致：%[5]s 的结果与 %[6]s 不同。",
+		"⚠️ Only %d of the %d required RPC endpoints confirmed the %s event of [tx %s](https://arbiscan.io/tx/%s) within %s, so it is ignored.":                                              "⚠️ 在 %[6]s 内仅有 %[1]d 个（共需 %[2]d 个）RPC 端点确认了 [tx %[4]s](https://arbiscan.io/tx/%[5]s) 的 %[3]s 事件，因此将其忽略。",
+		"❌ RPC %s serves chain ID %s instead of %d, so it is not used. Check that it is an endpoint of %s.":                                                                                  "❌ RPC %s 的链 ID 为 %s 而不是 %d，因此不会使用。请确认它是 %s 的端点。",
+		"⚠️ The reward call of %s in round %d ([tx %s](https://arbiscan.io/tx/%s)) was removed by a chain reorg. Reward is no longer called and the missed-reward warning is re-armed.":      "⚠️ %s 在第 %d 轮的 reward 调用（[tx %s](https://arbiscan.io/tx/%s)）因链重组被移除。reward 不再视为已调用，未调用 reward 的警告已重新启用。",
+		"⏳ Reward for %s in round %d is not confirmed yet, but reward transaction [%s](https://arbiscan.io/tx/%s) has been pending in the mempool for %s.":                                   "⏳ %s 第 %d 轮的 reward 尚未确认，但 reward 交易 [%s](https://arbiscan.io/tx/%s) 已在内存池中等待 %s。",
+		"❌ Reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d has been pending in the mempool for %s. It may be stuck, e.g. with a gas price below the current base fee.": "❌ %[3]s 第 %[4]d 轮的 reward 交易 [%[1]s](https://arbiscan.io/tx/%[2]s) 已在内存池中等待 %[5]s，可能已卡住，例如 gas 价格低于当前基础费用。",
+		"❌ Transaction [%s](https://arbiscan.io/tx/%s) from reward caller %s calling %s() on the BondingManager reverted: %s.":                                                               "❌ reward 调用账户 %[3]s 在 BondingManager 上调用 %[4]s() 的交易 [%[1]s](https://arbiscan.io/tx/%[2]s) 已回滚：%[5]s。",
+		"out of gas":     "gas 耗尽",
+		"unknown reason": "原因未知",
+		"❌ Auto-reward attempt %d/%d for %s in round %d failed: %v":                                                                                                      "❌ 第 %[4]d 轮为 %[3]s 自动调用 reward 的第 %[1]d/%[2]d 次尝试失败：%[5]v",
+		"🤖 Reward still missing for %s in round %d, so the watcher submitted reward() in transaction [%s](https://arbiscan.io/tx/%s) (attempt %d/%d).":                   "🤖 %s 在第 %d 轮仍未调用 reward，监控程序已在交易 [%s](https://arbiscan.io/tx/%s) 中提交 reward()（第 %d/%d 次尝试）。",
+		"❌ The auto-reward transaction for %s in round %d (attempt %d/%d) was not mined: %v":                                                                             "❌ %s 在第 %d 轮的自动 reward 交易（第 %d/%d 次尝试）未被打包：%v",
+		"❌ The auto-reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d reverted (attempt %d/%d).":                                                     "❌ %[3]s 在第 %[4]d 轮的自动 reward 交易 [%[1]s](https://arbiscan.io/tx/%[2]s) 已回滚（第 %[5]d/%[6]d 次尝试）。",
+		"✅ The watcher called reward for %s in round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                 "✅ 监控程序已在交易 [%[3]s](https://arbiscan.io/tx/%[4]s) 中为 %[1]s 调用第 %[2]d 轮的 reward。",
+		"❌ Failed to submit initializeRound() for round %d: %v":                                                                                                          "❌ 无法为第 %d 轮提交 initializeRound()：%v",
+		"❌ The initializeRound() transaction for round %d was not mined: %v":                                                                                             "❌ 第 %d 轮的 initializeRound() 交易未被打包：%v",
+		"❌ The initializeRound() transaction [%s](https://arbiscan.io/tx/%s) for round %d reverted.":                                                                     "❌ 第 %[3]d 轮的 initializeRound() 交易 [%[1]s](https://arbiscan.io/tx/%[2]s) 已回滚。",
+		"✅ The watcher initialized round %d in transaction [%s](https://arbiscan.io/tx/%s).":                                                                             "✅ 监控程序已在交易 [%[2]s](https://arbiscan.io/tx/%[3]s) 中初始化第 %[1]d 轮。",
+		"✅ Round %d has been initialized, reward can be called again.":                                                                                                   "✅ 第 %d 轮已初始化，可以再次调用 reward。",
+		"⚠️ Round %d started %s ago on L1 but has not been initialized yet, so no orchestrator can call reward. Anyone can call initializeRound() on the RoundsManager.": "⚠️ 第 %d 轮已在 L1 上开始 %s，但尚未初始化，因此任何编排器都无法调用 reward。任何人都可以在 RoundsManager 上调用 initializeRound()。",
+		"🚨 Last chance: %s has still not called reward in round %d and the round ends in approximately %s.":                                                              "🚨 最后机会：%s 在第 %d 轮仍未调用 reward，本轮将在约 %s 后结束。",
+		" Approximately %s remaining to call reward.":                                                                                                                    " 距离调用 reward 还剩约 %s。",
+		"⚠️ No reward called for %s in round %d yet after %s.":                                                                                                           "⚠️ %[1]s 在第 %[2]d 轮开始 %[3]s 后尚未调用 reward。",
+		"⏳ No reward called for %s in round %d yet after %s.":                                                                                                            "⏳ %[1]s 在第 %[2]d 轮开始 %[3]s 后尚未调用 reward。",
+		"%.0f%% of the round": "%.0f%% 的轮次时长",
+		"✅ Reward caller [%s](https://arbiscan.io/address/%s) was topped up to %s ETH.":                                                                 "✅ reward 调用账户 [%s](https://arbiscan.io/address/%s) 已充值至 %s ETH。",
+		"⚠️ Reward caller [%s](https://arbiscan.io/address/%s) has %s ETH left, below the minimum of %g ETH. Top it up so it can pay for reward calls.": "⚠️ reward 调用账户 [%s](https://arbiscan.io/address/%s) 仅剩 %s ETH，低于最低 %g ETH。请充值以支付 reward 调用。",
+		" Current gas price %s gwei exceeds the max gas price of %s gwei, so reward was likely skipped due to the gas ceiling.":                         " 当前 gas 价格 %s gwei 超过最高 gas 价格 %s gwei，reward 很可能因 gas 上限而被跳过。",
+		" Gas used: %d, fee: %s ETH%s.":                           " 消耗 Gas：%d，手续费：%s ETH%s。",
+		", %s ETH in transaction fees":                            "，交易手续费 %s ETH",
+		" %s LPT minted by all watched orchestrators this round.": " 本轮所有受监控的编排器共铸造 %s LPT。",
+		"%s digest: %d alert(s)":                                  "%s 摘要：%d 条告警",
+		"\n✅ Reward called":                                       "\n✅ 已调用 reward",
+		" %s after the round started":                             "（本轮开始后 %s）",
+		", %s LPT minted":                                         "，铸造 %s LPT",
+		".":                                                       "。",
+		"\n❌ Reward was not called.":                              "\n❌ 未调用 reward。",
+		"\n💰 %s ETH in fees earned from %d redeemed winning ticket(s).":                   "\n💰 通过兑换 %[2]d 张中奖票获得 %[1]s ETH 手续费。",
+		"\n🏛 Treasury contribution: %s LPT this round, %s LPT since the watcher started.": "\n🏛 国库贡献：本轮 %s LPT，自 watcher 启动以来 %s LPT。",
+		"\n📈 Stake: %s%s LPT (total %s LPT).":                                             "\n📈 质押：%s%s LPT（总计 %s LPT）。",
+		" · round %d started %s (%s ago)":                                                 " · 第 %d 轮开始于 %s（%s 前）",
+	},
+}
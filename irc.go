@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// ircConfig configures the IRC alert channel.
+type ircConfig struct {
+	// Server is the host:port of the IRC server.
+	Server  string `json:"server"`
+	TLS     bool   `json:"tls"`
+	Nick    string `json:"nick"`
+	Channel string `json:"channel"`
+	// NickServPassword identifies the nick with NickServ before joining, if set.
+	NickServPassword string `json:"nickservPassword"`
+}
+
+func (c ircConfig) complete() bool {
+	return c.Server != "" && c.Nick != "" && c.Channel != ""
+}
+
+// sendIRCAlert connects to the IRC server, joins the channel and posts the alert line by line.
+func sendIRCAlert(cfg ircConfig, message string) error {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if cfg.TLS {
+		host, _, _ := net.SplitHostPort(cfg.Server)
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Server, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Server)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(60 * time.Second))
+	send := func(format string, args ...interface{}) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	nick := cfg.Nick
+	send("NICK %s", nick)
+	send("USER %s 0 * :Livepeer Reward Watcher", cfg.Nick)
+	reader := bufio.NewReader(conn)
+	joined := false
+	for !joined {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("IRC connection closed: %v", err)
+		}
+		fields := strings.Fields(strings.TrimSpace(line))
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "PING" {
+			send("PONG %s", strings.Join(fields[1:], " "))
+			continue
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[1] {
+		case "001": // Welcome: registration is complete.
+			if cfg.NickServPassword != "" {
+				send("PRIVMSG NickServ :IDENTIFY %s %s", cfg.Nick, cfg.NickServPassword)
+			}
+			send("JOIN %s", cfg.Channel)
+		case "433": // Nickname in use.
+			nick += "_"
+			send("NICK %s", nick)
+		case "366": // End of the channel's names list: the channel was joined.
+			joined = true
+		case "403", "405", "471", "473", "474", "475", "477":
+			return fmt.Errorf("can't join %s: %s", cfg.Channel, strings.TrimSpace(line))
+		}
+	}
+
+	// IRC messages are single lines, so links are written out and every line is sent separately.
+	for _, line := range strings.Split(markdownLinkRe.ReplaceAllString(message, "$1 ($2)"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		send("PRIVMSG %s :%s", cfg.Channel, line)
+	}
+	send("QUIT :Alert sent")
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeIRCServer accepts one client and answers every line it receives with the lines returned by
+// respond. It returns the address to connect to and a channel that receives the client's lines
+// once the connection is closed.
+func fakeIRCServer(t *testing.T, respond func(line string) []string) (string, <-chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	received := make(chan []string, 1)
+	go func() {
+		var lines []string
+		defer func() { received <- lines }()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			line := strings.TrimSuffix(scanner.Text(), "\r")
+			lines = append(lines, line)
+			for _, reply := range respond(line) {
+				fmt.Fprintf(conn, "%s\r\n", reply)
+			}
+			if strings.HasPrefix(line, "QUIT") {
+				return
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestSendIRCAlert(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ircConfig
+		respond func(line string) []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "registers, joins and posts every line",
+			cfg:  ircConfig{Nick: "watcher", Channel: "#alerts"},
+			respond: func(line string) []string {
+				switch {
+				case strings.HasPrefix(line, "USER"):
+					return []string{"PING :irc.example.com", ":irc.example.com 001 watcher :Welcome"}
+				case strings.HasPrefix(line, "JOIN"):
+					return []string{":watcher JOIN #alerts", ":irc.example.com 353 watcher = #alerts :watcher", ":irc.example.com 366 watcher #alerts :End of /NAMES list."}
+				}
+				return nil
+			},
+			want: []string{
+				"NICK watcher",
+				"USER watcher 0 * :Livepeer Reward Watcher",
+				"PONG :irc.example.com",
+				"JOIN #alerts",
+				"PRIVMSG #alerts :⚠️ Reward not called",
+				"PRIVMSG #alerts :See explorer (https://explorer.livepeer.org)",
+				"QUIT :Alert sent",
+			},
+		},
+		{
+			name: "picks another nick and identifies with NickServ",
+			cfg:  ircConfig{Nick: "watcher", Channel: "#alerts", NickServPassword: "hunter2"},
+			respond: func(line string) []string {
+				switch line {
+				case "USER watcher 0 * :Livepeer Reward Watcher":
+					return []string{":irc.example.com 433 * watcher :Nickname is already in use"}
+				case "NICK watcher_":
+					return []string{":irc.example.com 001 watcher_ :Welcome"}
+				case "JOIN #alerts":
+					return []string{":irc.example.com 366 watcher_ #alerts :End of /NAMES list."}
+				}
+				return nil
+			},
+			want: []string{
+				"NICK watcher",
+				"USER watcher 0 * :Livepeer Reward Watcher",
+				"NICK watcher_",
+				"PRIVMSG NickServ :IDENTIFY watcher hunter2",
+				"JOIN #alerts",
+				"PRIVMSG #alerts :⚠️ Reward not called",
+				"PRIVMSG #alerts :See explorer (https://explorer.livepeer.org)",
+				"QUIT :Alert sent",
+			},
+		},
+		{
+			name: "fails when the channel can't be joined",
+			cfg:  ircConfig{Nick: "watcher", Channel: "#alerts"},
+			respond: func(line string) []string {
+				switch {
+				case strings.HasPrefix(line, "USER"):
+					return []string{":irc.example.com 001 watcher :Welcome"}
+				case strings.HasPrefix(line, "JOIN"):
+					return []string{":irc.example.com 473 watcher #alerts :Cannot join channel (+i)"}
+				}
+				return nil
+			},
+			wantErr: "can't join #alerts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, received := fakeIRCServer(t, tt.respond)
+			tt.cfg.Server = addr
+			err := sendIRCAlert(tt.cfg, "⚠️ Reward not called\n\nSee [explorer](https://explorer.livepeer.org)")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("sendIRCAlert() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := <-received; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server received:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+// kafkaConfig configures the Kafka event sink.
+type kafkaConfig struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+	TLS     bool     `json:"tls"`
+	// Username and Password enable SASL/PLAIN authentication.
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (c kafkaConfig) complete() bool {
+	return len(c.Brokers) > 0 && c.Topic != ""
+}
+
+// kafkaConn is a producer for the topic of a Kafka sink. It keeps its broker connections open
+// between events.
+type kafkaConn struct {
+	writer *kafka.Writer
+}
+
+// dialKafka creates the producer. It connects to the brokers on the first event.
+func dialKafka(cfg kafkaConfig) (*kafkaConn, error) {
+	transport := &kafka.Transport{}
+	if cfg.TLS {
+		transport.TLS = &tls.Config{}
+	}
+	if cfg.Username != "" {
+		transport.SASL = plain.Mechanism{Username: cfg.Username, Password: cfg.Password}
+	}
+	return &kafkaConn{writer: &kafka.Writer{
+		Addr:  kafka.TCP(cfg.Brokers...),
+		Topic: cfg.Topic,
+		// Keys events by orchestrator, so each orchestrator's events stay in order on one partition.
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		Transport:    transport,
+	}}, nil
+}
+
+// kafkaMessage returns the record of an event: the event as JSON, keyed by orchestrator (or by
+// label for events that aren't about an orchestrator).
+func kafkaMessage(e event) (kafka.Message, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	key := e.Orchestrator
+	if key == "" {
+		key = e.Label
+	}
+	return kafka.Message{Key: []byte(key), Value: value, Time: time.Unix(e.Timestamp, 0)}, nil
+}
+
+// publish produces an event and waits for all in-sync replicas to acknowledge it.
+func (c *kafkaConn) publish(e event) error {
+	msg, err := kafkaMessage(e)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return c.writer.WriteMessages(ctx, msg)
+}
+
+func (c *kafkaConn) Close() error {
+	return c.writer.Close()
+}
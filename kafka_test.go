@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestKafkaMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		e       event
+		wantKey string
+	}{
+		{
+			name:    "keyed by orchestrator",
+			e:       event{Kind: "chain", Type: "Reward", Orchestrator: "0xabc", Label: "arbitrum", Timestamp: 1700000000},
+			wantKey: "0xabc",
+		},
+		{
+			name:    "keyed by label without orchestrator",
+			e:       event{Kind: "lifecycle", Type: "rpc_connected", Label: "arbitrum", Timestamp: 1700000000},
+			wantKey: "arbitrum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := kafkaMessage(tt.e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(msg.Key) != tt.wantKey {
+				t.Errorf("key = %q, want %q", msg.Key, tt.wantKey)
+			}
+			if !msg.Time.Equal(time.Unix(tt.e.Timestamp, 0)) {
+				t.Errorf("time = %s, want the event time", msg.Time)
+			}
+			var got event
+			if err := json.Unmarshal(msg.Value, &got); err != nil || got != tt.e {
+				t.Errorf("value = %s, want the event as JSON", msg.Value)
+			}
+		})
+	}
+}
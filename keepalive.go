@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// staleHeadTimeout is how long the chain head may stand still before the connection is
+// considered half-dead. Arbitrum produces several blocks per second.
+const staleHeadTimeout = time.Minute
+
+// keepaliveState is the last chain head seen by the connection probe and when it changed.
+type keepaliveState struct {
+	head  uint64
+	since time.Time
+}
+
+// probeConnection reconnects when the RPC connection stops answering or its chain head stops
+// moving, so half-dead WebSocket connections are noticed within a minute instead of when a
+// subscription finally fails.
+func (w *watcher) probeConnection() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		w.log.Printf("Connection probe failed, reconnecting: %v", err)
+		w.reconnectRequested = true
+		return
+	}
+	if head != w.keepalive.head {
+		w.keepalive = keepaliveState{head: head, since: time.Now()}
+		return
+	}
+	if stale := time.Since(w.keepalive.since); stale >= staleHeadTimeout {
+		w.log.Printf("Chain head stuck at block %d for %s, reconnecting", head, formatDuration(stale.Round(time.Second)))
+		w.keepalive = keepaliveState{}
+		w.reconnectRequested = true
+	}
+}
@@ -2,33 +2,23 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"html"
 	"log"
-	"net"
+	"math/big"
 	"net/http"
-	"net/smtp"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-// Contract: https://arbiscan.io/address/0x35Bcf3c30594191d53231E4FF333E8A770453e40
-var bondingManager = common.HexToAddress("0x35Bcf3c30594191d53231E4FF333E8A770453e40")
-
-// RoundsManager contract: https://arbiscan.io/address/0xdd6f56DcC28D3F5f27084381fE8Df634985cc39f
-var roundsManager = common.HexToAddress("0xdd6f56DcC28D3F5f27084381fE8Df634985cc39f")
-
 // maskRPCURL returns a safe display form of the RPC URL, omitting secrets.
 func maskRPCURL(raw string) string {
 	u, err := url.Parse(raw)
@@ -41,119 +31,96 @@ func maskRPCURL(raw string) string {
 	return masked
 }
 
-// connectToRPC tries to connect to one of the provided RPC URLs and returns the first that works.
-func connectToRPC(rpcs []string) (*ethclient.Client, string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	for _, url := range rpcs {
-		c, err := ethclient.DialContext(ctx, url)
-		if err == nil {
-			_, err2 := c.BlockNumber(ctx)
-			if err2 == nil {
-				return c, url, nil
-			}
-			c.Close()
-		}
+// loadABI reads and parses a contract ABI downloaded at build time.
+func loadABI(name string) (abi.ABI, error) {
+	abiBytes, err := os.ReadFile(fmt.Sprintf("ABIs/%s.json", name))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to read %s ABI file: %v (run 'make download-abis' to download ABIs)", name, err)
 	}
-	return nil, "", fmt.Errorf("all RPCs failed")
+	parsed, err := abi.JSON(strings.NewReader(string(abiBytes)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse %s ABI: %v", name, err)
+	}
+	return parsed, nil
 }
 
-// sendDiscordAlert sends a message to a Discord channel using a webhook, with color.
-func sendDiscordAlert(webhookURL, message string, color int) error {
-	payload := map[string]interface{}{
-		"embeds": []map[string]interface{}{
-			{
-				"title":       "Livepeer Reward watcher Alert",
-				"description": message,
-				"color":       color,
-			},
-		},
-	}
-	body, _ := json.Marshal(payload)
-	resp, err := http.Post(webhookURL, "application/json", strings.NewReader(string(body)))
-	if err != nil {
-		return err
+// formatUnits formats a token amount with the given number of decimals (e.g. 18 for ETH/LPT).
+func formatUnits(amount *big.Int, decimals int, precision int) string {
+	if amount == nil {
+		return "0"
 	}
-	defer resp.Body.Close()
-	return nil
+	value := new(big.Float).SetInt(amount)
+	value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return value.Text('f', precision)
 }
 
-type EmailConfig struct {
-	Host     string
-	Port     string
-	Username string
-	Password string
-	From     string
-	To       []string
+// rpcClientOptions are applied to every RPC connection (e.g. mutual TLS settings).
+var rpcClientOptions []rpc.ClientOption
+
+// connectToRPC tries to connect to one of the provided RPC URLs and returns the first that works
+// and serves the expected chain. Endpoints of another chain are refused and passed to wrongChain.
+// With wssUpgrade, the wss:// variant of an https:// URL is tried first, since providers offering
+// both serve subscriptions only over WebSocket.
+func connectToRPC(rpcs []string, chainID uint64, wssUpgrade bool, wrongChain func(url string, got *big.Int)) (*ethclient.Client, string, error) {
+	for _, url := range rpcs {
+		if wss := wssVariant(url); wssUpgrade && wss != "" {
+			if c, err := dialRPC(wss, chainID); err == nil {
+				log.Printf("Using WebSocket endpoint %s for %s", maskRPCURL(wss), maskRPCURL(url))
+				return c, url, nil
+			}
+		}
+		c, err := dialRPC(url, chainID)
+		if err == nil {
+			return c, url, nil
+		}
+		var mismatch *chainMismatchError
+		if errors.As(err, &mismatch) {
+			wrongChain(url, mismatch.got)
+		}
+	}
+	return nil, "", fmt.Errorf("all RPCs failed")
 }
 
-func (c EmailConfig) complete() bool {
-	return c.Host != "" && c.From != "" && len(c.To) > 0 && c.Username != "" && c.Password != ""
+// chainMismatchError is returned by dialRPC for an endpoint of another chain.
+type chainMismatchError struct {
+	got *big.Int
 }
 
-// sendEmailAlert sends an HTML email using SMTP.
-func sendEmailAlert(cfg EmailConfig, subject, htmlBody string) error {
-	if !cfg.complete() {
-		return fmt.Errorf("email config is incomplete")
-	}
-	auth := smtp.Auth(nil)
-	if cfg.Username != "" {
-		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
-	}
-	addr := net.JoinHostPort(cfg.Host, cfg.Port)
-	headers := []string{
-		fmt.Sprintf("From: %s", cfg.From),
-		fmt.Sprintf("To: %s", strings.Join(cfg.To, ", ")),
-		fmt.Sprintf("Subject: %s", subject),
-		"MIME-Version: 1.0",
-		"Content-Type: text/html; charset=UTF-8",
-	}
-	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + htmlBody + "\r\n"
-	return smtp.SendMail(addr, auth, cfg.From, cfg.To, []byte(body))
+func (e *chainMismatchError) Error() string {
+	return fmt.Sprintf("unexpected chain ID %s", e.got)
 }
 
-// sendAlert sends alerts to messaging platforms based on configuration.
-func sendAlert(botToken, chatID, discordWebhook string, emailCfg EmailConfig, message string, color int) error {
-	var failed []string
-	if discordWebhook != "" {
-		if err := sendDiscordAlert(discordWebhook, message, color); err != nil {
-			log.Printf("Discord alert error: %v", err)
-			failed = append(failed, "Discord")
-		}
+// dialRPC connects to an RPC endpoint and checks that it responds and serves the chain.
+func dialRPC(url string, chainID uint64) (*ethclient.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rc, err := rpc.DialOptions(ctx, url, rpcDialOptions(url)...)
+	if err != nil {
+		return nil, err
 	}
-	if botToken != "" && chatID != "" {
-		if err := sendTelegramAlert(botToken, chatID, message); err != nil {
-			log.Printf("Telegram alert error: %v", err)
-			failed = append(failed, "Telegram")
-		}
+	c := ethclient.NewClient(rc)
+	if _, err := c.BlockNumber(ctx); err != nil {
+		c.Close()
+		return nil, err
 	}
-	if emailCfg.complete() {
-		htmlBody := markdownToHTML(strings.TrimSpace(message))
-		if err := sendEmailAlert(emailCfg, "Livepeer Reward Watcher Alert", htmlBody); err != nil {
-			log.Printf("Email alert error: %v", err)
-			failed = append(failed, "Email")
-		}
+	got, err := c.ChainID(ctx)
+	if err != nil {
+		c.Close()
+		return nil, err
 	}
-	if len(failed) > 0 {
-		return fmt.Errorf("alert failed for: %s", strings.Join(failed, ", "))
+	if got.Cmp(new(big.Int).SetUint64(chainID)) != 0 {
+		c.Close()
+		return nil, &chainMismatchError{got: got}
 	}
-	return nil
+	return c, nil
 }
 
-var markdownLinkRe = regexp.MustCompile(`\[(.*?)\]\((.*?)\)`)
-
-// markdownToHTML converts a markdown-formatted message to HTML.
-func markdownToHTML(message string) string {
-	body := html.EscapeString(message)
-	body = markdownLinkRe.ReplaceAllStringFunc(body, func(match string) string {
-		parts := markdownLinkRe.FindStringSubmatch(match)
-		if len(parts) != 3 {
-			return match
-		}
-		return fmt.Sprintf(`<a href="%s">%s</a>`, parts[2], parts[1])
-	})
-	body = strings.ReplaceAll(body, "\n", "<br>")
-	return "<html><body><p>" + body + "</p></body></html>"
+// wssVariant returns the wss:// URL of an https:// RPC URL, or "" for other schemes.
+func wssVariant(url string) string {
+	if rest, ok := strings.CutPrefix(url, "https://"); ok {
+		return "wss://" + rest
+	}
+	return ""
 }
 
 // splitCSV splits a comma-separated string into a slice of trimmed strings.
@@ -172,213 +139,547 @@ func splitCSV(raw string) []string {
 	return out
 }
 
-// sendTelegramAlert sends a message to a Telegram chat using a bot.
-func sendTelegramAlert(botToken, chatID, message string) error {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
-	payload := map[string]string{"chat_id": chatID, "text": message, "parse_mode": "Markdown"}
-	body, _ := json.Marshal(payload)
-	resp, err := http.Post(url, "application/json", strings.NewReader(string(body)))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	return nil
+// options holds the command line settings shared by all watchers.
+type options struct {
+	delay                   time.Duration
+	delayProgress           float64
+	warningStages           []float64
+	finalCall               time.Duration
+	uninitializedRoundDelay time.Duration
+	autoInitializeRound     time.Duration
+	autoReward              float64
+	txGasLimit              uint64
+	txSigner                *txSigner
+	txMaxGasPrice           *big.Int
+	checkInterval           time.Duration
+	repeat                  bool
+	voiceCallAfter          int
+	disableSuccessAlerts    bool
+	disableRoundAlerts      bool
+	disableRoundSummary     bool
+	enableRPCAlerts         bool
+	gasAnomalyThreshold     float64
+	fiatCurrency            string
+	maxGasPrice             *big.Int
+	minCallerBalance        float64
+	networkStallTimeout     time.Duration
+	roundStallTimeout       time.Duration
+	stuckTxTimeout          time.Duration
+	serviceURICheckInterval time.Duration
+	serviceURIVerifyTLS     bool
+	certExpiryWarning       time.Duration
+	versionCheckInterval    time.Duration
+	maxReleasesBehind       int
+	rpcListRefresh          time.Duration
+	explorerAPIKey          string
+	abiRefreshInterval      time.Duration
+	abiCacheDir             string
+	maxClaimLag             uint64
+	headLagThreshold        uint64
+	catchUpBlocks           uint64
+	logChunkSize            uint64
+	headLagCheckInterval    time.Duration
+	revertScanInterval      time.Duration
+	confirmations           uint64
+	quorum                  int
+	pollInterval            time.Duration
+	keepaliveInterval       time.Duration
+	rpcHealthInterval       time.Duration
+	primaryCheckInterval    time.Duration
+	wssUpgrade              bool
+	maxRetryTime            time.Duration
 }
 
 func main() {
+	// Scrub secrets from all log output.
+	log.SetOutput(logOutput)
+
 	// Parse command line flags.
-	delayFlag := flag.Duration("delay", 2*time.Hour, "Time to wait after new round before warning (e.g. 2h, 30m)")
-	checkIntervalFlag := flag.Duration("check-interval", 1*time.Hour, "How often to check and repeat warning if reward not called (e.g. 1h)")
-	repeatFlag := flag.Bool("repeat", true, "Repeat warning every check-interval (true) or only send once per round (false)")
-	disableSuccessAlertsFlag := flag.Bool("disable-success-alerts", false, "Disable alerts when rewards are successfully called (default: false)")
-	disableRoundAlertsFlag := flag.Bool("disable-round-alerts", false, "Disable alerts when new rounds start (default: false)")
-	enableRPCAlertsFlag := flag.Bool("enable-rpc-alerts", false, "Enable alerts for RPC disconnects/reconnects and subscription errors (default: false)")
-	maxRetryTimeFlag := flag.Duration("max-retry-time", 30*time.Minute, "Max time to retry RPC connections before giving up (0 = retry forever)")
+	var opts options
+	flag.DurationVar(&opts.delay, "delay", 2*time.Hour, "Time to wait after new round before warning (e.g. 2h, 30m)")
+	flag.DurationVar(&opts.uninitializedRoundDelay, "uninitialized-round-delay", 0, "Alert when a new round has started on L1 but hasn't been initialized for this long (0 = disabled)")
+	flag.DurationVar(&opts.autoInitializeRound, "auto-initialize-round", 0, "Submit initializeRound() from the watcher's own account when the round stays uninitialized this long (requires TX_KEYSTORE or TX_PRIVATE_KEY, 0 = disabled)")
+	flag.Float64Var(&opts.autoReward, "auto-reward", 0, "Call reward() from the watcher's own account when it is still missing after this percentage of the round (requires TX_KEYSTORE or TX_PRIVATE_KEY of the orchestrator, 0 = disabled)")
+	flag.Uint64Var(&opts.txGasLimit, "tx-gas-limit", 0, "Don't submit the watcher's own transactions when they need more gas than this (0 = no limit)")
+	txMaxGasPriceFlag := flag.String("tx-max-gas-price", "0.1gwei", "Don't submit the watcher's own transactions while the gas price exceeds this, in wei or e.g. 0.1gwei (empty = no limit)")
+	finalCallFlag := flag.String("final-call", "", "Send a critical last-chance alert when reward is still missing this long before the round ends, as a duration (e.g. 30m) or L1 blocks (e.g. 150blocks)")
+	warningStagesFlag := flag.String("warning-stages", "", "Comma-separated percentages of the round at which to warn with increasing severity, e.g. 50,75,90 (overrides --delay and --delay-progress)")
+	flag.Float64Var(&opts.delayProgress, "delay-progress", 0, "Warn once this percentage of the round's blocks has elapsed instead of after --delay (e.g. 10, 0 = disabled)")
+	flag.DurationVar(&opts.checkInterval, "check-interval", 1*time.Hour, "How often to check and repeat warning if reward not called (e.g. 1h)")
+	flag.BoolVar(&opts.repeat, "repeat", true, "Repeat warning every check-interval (true) or only send once per round (false)")
+	flag.IntVar(&opts.voiceCallAfter, "voice-call-after", 0, "Escalate to a Twilio phone call once the missed-reward warning was sent this many times in a round (0 = disabled)")
+	flag.BoolVar(&opts.disableSuccessAlerts, "disable-success-alerts", false, "Disable alerts when rewards are successfully called (default: false)")
+	flag.BoolVar(&opts.disableRoundAlerts, "disable-round-alerts", false, "Disable alerts when new rounds start (default: false)")
+	flag.BoolVar(&opts.disableRoundSummary, "disable-round-summary", false, "Disable the end-of-round summary alert with reward, fees and stake change (default: false)")
+	flag.BoolVar(&opts.enableRPCAlerts, "enable-rpc-alerts", false, "Enable alerts for RPC disconnects/reconnects and subscription errors (default: false)")
+	flag.StringVar(&opts.fiatCurrency, "fiat-currency", "", "Append the value of minted LPT and reward fees in this currency (e.g. usd, eur) to reward alerts, using CoinGecko prices (empty = disabled)")
+	maxGasPriceFlag := flag.String("max-gas-price", "", "The orchestrator's -maxGasPrice in wei (or e.g. 0.1gwei); missed-reward warnings say when the current gas price exceeds it (empty = disabled)")
+	flag.Float64Var(&opts.gasAnomalyThreshold, "gas-anomaly-threshold", 0, "Alert when a reward call's gas usage deviates from the recent average by more than this fraction (0 = disabled)")
+	flag.DurationVar(&opts.networkStallTimeout, "network-stall-timeout", 0, "Alert when no Reward event is seen network-wide for this long (0 = disabled)")
+	flag.DurationVar(&opts.roundStallTimeout, "round-stall-timeout", 0, "Alert when no NewRound event is seen for this long (0 = disabled)")
+	exportCSVFlag := flag.String("export-csv", "", "Append reward and fee events with USD prices to this CSV file for tax/accounting (empty = disabled)")
+	exportFormatFlag := flag.String("export-format", "generic", "Format of the CSV export: generic or koinly")
+	flag.DurationVar(&opts.maxRetryTime, "max-retry-time", 30*time.Minute, "Max time to retry RPC connections before giving up (0 = retry forever)")
+	flag.DurationVar(&opts.stuckTxTimeout, "stuck-tx-timeout", 0, "Alert when the reward caller has transactions pending for this long (0 = disabled)")
+	flag.Float64Var(&opts.minCallerBalance, "min-caller-balance", 0, "Alert when the reward caller's ETH balance drops below this amount (0 = disabled)")
+	flag.DurationVar(&opts.serviceURICheckInterval, "service-uri-check-interval", 0, "How often to check that the orchestrator's ServiceURI is reachable (0 = disabled)")
+	flag.BoolVar(&opts.serviceURIVerifyTLS, "service-uri-verify-tls", false, "Require a TLS certificate trusted by the system roots on the ServiceURI (default: false, go-livepeer uses self-signed certificates)")
+	flag.DurationVar(&opts.certExpiryWarning, "cert-expiry-warning", 14*24*time.Hour, "Warn when the ServiceURI TLS certificate expires within this duration (0 = disabled)")
+	nodeStatusURLFlag := flag.String("node-status-url", "", "go-livepeer status endpoint of the orchestrator node used for version checks (e.g. http://127.0.0.1:7935/status)")
+	flag.DurationVar(&opts.versionCheckInterval, "version-check-interval", 6*time.Hour, "How often to compare the node's go-livepeer version against the latest release")
+	flag.IntVar(&opts.maxReleasesBehind, "max-releases-behind", 1, "Alert when the node is more than this many go-livepeer releases behind")
+	announceReleasesFlag := flag.Bool("announce-releases", false, "Announce new go-livepeer releases to the alert channels (default: false)")
+	releaseCheckIntervalFlag := flag.Duration("release-check-interval", 1*time.Hour, "How often to check for new go-livepeer releases")
+	telegramCommandsFlag := flag.Bool("telegram-commands", false, "Answer /status, /round, /lastreward and /mute commands in the Telegram alert chat (long polling)")
+	threadAlertsFlag := flag.Bool("thread-alerts", false, "Post follow-up alerts of a round as replies to the round's first message where supported (Telegram)")
+	discordRoundThreadsFlag := flag.Bool("discord-round-threads", false, "Post each round's alerts in a \"Round N\" thread; DISCORD_WEBHOOK_URL must belong to a forum channel, or set DISCORD_BOT_TOKEN")
+	listenFlag := flag.String("listen", "", "Serve the JSON watcher status on /status and Prometheus metrics on /metrics at this address, e.g. :8080 (empty = disabled)")
+	metricsAddrFlag := flag.String("metrics-addr", "", "Alias of --listen")
+	stateFileFlag := flag.String("state-file", "", "Persist the round state to this JSON file so restarts keep it (empty = disabled)")
+	statusFileFlag := flag.String("status-file", "", "Continuously write the watcher state as JSON to this file for external monitors (empty = disabled)")
+	tlsClientCertFlag := flag.String("tls-client-cert", "", "Client certificate (PEM) presented to RPC endpoints and the generic webhook for mutual TLS")
+	tlsClientKeyFlag := flag.String("tls-client-key", "", "Private key (PEM) of the mutual TLS client certificate")
+	tlsCABundleFlag := flag.String("tls-ca-bundle", "", "Additional CA certificates (PEM) trusted for RPC endpoints and the generic webhook")
+	rpcListURLFlag := flag.String("rpc-list-url", "", "URL of a JSON RPC endpoint list (array, {\"rpcs\": [...]} or chainlist-style) that replaces the RPC arguments")
+	flag.DurationVar(&opts.rpcListRefresh, "rpc-list-refresh", 1*time.Hour, "How often to refresh the remote RPC endpoint list")
+	flag.DurationVar(&opts.abiRefreshInterval, "abi-refresh-interval", 24*time.Hour, "How often to check for contract upgrades and refresh ABIs from Arbiscan when ARBISCAN_API_KEY is set (0 = only on Controller updates)")
+	flag.StringVar(&opts.abiCacheDir, "abi-cache-dir", "ABIs/cache", "Directory where ABIs fetched at runtime are cached")
+	minSeverityFlag := flag.String("min-severity", "", "Comma-separated per-channel minimum severities (info, warning, critical), e.g. \"email=warning,pushover=critical\"; \"*\" applies to all other channels")
+	quietHoursFlag := flag.String("quiet-hours", "", "Daily window in local time during which only critical alerts are sent, e.g. \"23:00-07:00\"")
+	quietHoursQueueFlag := flag.Bool("quiet-hours-queue", false, "Send the alerts suppressed during quiet hours when the window ends instead of dropping them")
+	quietHoursChannelsFlag := flag.String("quiet-hours-channels", "", "Comma-separated channels that receive critical alerts during quiet hours (default: all)")
+	dedupWindowFlag := flag.Duration("dedup-window", 0, "Collapse identical alerts sent within this window into one with a repeat counter (0 = disabled)")
+	rateLimitFlag := flag.Int("rate-limit", 0, "Maximum number of alerts sent per minute (0 = unlimited)")
+	channelRateLimitsFlag := flag.String("channel-rate-limits", "", "Comma-separated per-channel limits of alerts per minute, e.g. \"sms=1,email=5\"")
+	escalationFlag := flag.String("escalation", "", "Escalation ladder for missed rewards, e.g. \"0s=discord;2h=telegram;4h=pagerduty,sms\"; each stage adds channels once reward has been missing that long since the first warning; stages follow the reward, not whether anyone acknowledged the alert")
+	langFlag := flag.String("lang", "en", "Language of the alert texts: en, es, de or zh")
+	tzFlag := flag.String("tz", "", "IANA time zone of the times in alerts and of quiet hours, e.g. Europe/Amsterdam (default: local time zone)")
+	timestampsFlag := flag.Bool("alert-timestamps", true, "Add the alert time and, for round alerts, the round start time to alerts")
+	templatesDirFlag := flag.String("templates-dir", "", "Directory with text/template files (<alert type>.tmpl, default.tmpl) that override the alert texts")
+	routesFlag := flag.String("routes", "", "Alert routing rules, e.g. \"new-round=discord;reward-missing=telegram,pagerduty;reward-success=\"; alert types without a rule go to every channel")
+	fallbackChannelsFlag := flag.String("fallback-channels", "", "Comma-separated alert channels (e.g. email) that only receive alerts when delivery to all other channels fails; alerts that are delivered but never acknowledged don't trigger them, use --escalation for that")
+	delegatorsFlag := flag.String("delegators", "", "Comma-separated delegator addresses whose earnings claim status is watched")
+	flag.Uint64Var(&opts.maxClaimLag, "max-claim-lag", 30, "Alert when a watched delegator's lastClaimRound is more than this many rounds behind (0 = disabled)")
+	flag.Uint64Var(&opts.headLagThreshold, "head-lag-threshold", 0, "Alert when the last processed block falls more than this many blocks behind the chain head of a reference RPC (0 = disabled)")
+	flag.DurationVar(&opts.headLagCheckInterval, "head-lag-check-interval", 1*time.Minute, "How often to compare the last processed block against the reference RPC")
+	flag.DurationVar(&opts.rpcHealthInterval, "rpc-health-interval", 0, "How often to probe every RPC for latency, errors and block height to connect to the healthiest one (0 = use the first RPC that answers)")
+	flag.DurationVar(&opts.primaryCheckInterval, "primary-check-interval", 0, "How often to probe the first RPC after failing over to a backup, switching back once it is healthy (0 = stay on the backup)")
+	flag.DurationVar(&opts.keepaliveInterval, "keepalive-interval", 0, "How often to probe the RPC connection; it is reconnected when a probe fails or the chain head stops moving for a minute (0 = disabled)")
+	flag.BoolVar(&opts.wssUpgrade, "wss-upgrade", false, "Try the wss:// variant of https:// RPC URLs first")
+	flag.DurationVar(&opts.pollInterval, "poll-interval", 10*time.Second, "How often to poll for new events on RPC endpoints without subscription support, e.g. plain HTTPS")
+	flag.IntVar(&opts.quorum, "quorum", 0, "Only count an event once this many RPC endpoints, including the connected one, have it in the transaction receipt, and alert when they disagree (0 = disabled)")
+	flag.Uint64Var(&opts.confirmations, "confirmations", 0, "Handle events only once they are this many blocks deep, so reorged-out events are dropped (0 = immediately)")
+	flag.DurationVar(&opts.revertScanInterval, "revert-scan-interval", 0, "How often to look for reverted BondingManager transactions of the reward caller (0 = disabled)")
+	flag.Uint64Var(&opts.catchUpBlocks, "catch-up-blocks", 400000, "How many blocks to search back for the current round's start to replay missed events at startup; the catch-up never queries more blocks than this (0 = disabled)")
+	flag.Uint64Var(&opts.logChunkSize, "log-chunk-size", 10000, "Maximum block range of a single historical log query")
+	referenceRPCFlag := flag.String("reference-rpc", "", "RPC endpoint used as the chain head reference for --head-lag-threshold (default: another endpoint from the RPC list)")
+	rewardCallerFlag := flag.String("reward-caller", "", "Address that submits reward transactions if different from the orchestrator, or a comma-separated list matching the orchestrators (default: orchestrator address)")
+	configFlag := flag.String("config", "", "Path to a JSON, YAML or TOML config file with options and the networks to watch")
 	flag.Parse()
 	args := flag.Args()
-	if len(args) < 1 {
-		log.Fatalf("Usage: %s <orchestrator-address> [rpc1 rpc2 ...]", os.Args[0])
-	}
-	orch := common.HexToAddress(args[0])
-	rpcs := []string{"https://arb1.arbitrum.io/rpc"}
-	if len(args) > 1 {
-		rpcs = args[1:]
+
+	// Apply options from the config file; flags given on the command line take precedence.
+	var cfg *fileConfig
+	if *configFlag != "" {
+		var err error
+		if cfg, err = loadConfig(*configFlag); err != nil {
+			log.Fatal(err)
+		}
+		if err := cfg.applyOptions(flag.CommandLine); err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
 	}
 
 	// Load config values from environment.
-	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	chatID := os.Getenv("TELEGRAM_CHAT_ID")
-	discordWebhook := os.Getenv("DISCORD_WEBHOOK_URL")
-	emailCfg := EmailConfig{
-		Host:     os.Getenv("SMTP_HOST"),
-		Port:     os.Getenv("SMTP_PORT"),
-		Username: os.Getenv("SMTP_USER"),
-		Password: os.Getenv("SMTP_PASS"),
-		From:     os.Getenv("EMAIL_FROM"),
-		To:       splitCSV(os.Getenv("EMAIL_TO")),
-	}
-	if emailCfg.Host != "" && emailCfg.Port == "" {
-		emailCfg.Port = "587"
-	}
-	if discordWebhook == "" && (botToken == "" || chatID == "") && !emailCfg.complete() {
-		log.Fatal("Set DISCORD_WEBHOOK_URL, or both TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID, or email SMTP settings")
+	opts.explorerAPIKey = os.Getenv("ARBISCAN_API_KEY")
+	registerSecret(opts.explorerAPIKey)
+	registerSecret(os.Getenv("TX_KEYSTORE_PASSWORD"))
+	registerSecret(os.Getenv("TX_PRIVATE_KEY"))
+	defaultNotifier := notifier{
+		TelegramBotToken:  os.Getenv("TELEGRAM_BOT_TOKEN"),
+		TelegramChatID:    os.Getenv("TELEGRAM_CHAT_ID"),
+		DiscordWebhook:    os.Getenv("DISCORD_WEBHOOK_URL"),
+		SlackWebhook:      os.Getenv("SLACK_WEBHOOK_URL"),
+		TeamsWebhook:      os.Getenv("TEAMS_WEBHOOK_URL"),
+		GoogleChatWebhook: os.Getenv("GOOGLE_CHAT_WEBHOOK_URL"),
+		RocketChatWebhook: os.Getenv("ROCKETCHAT_WEBHOOK_URL"),
+		Mattermost: mattermostConfig{
+			WebhookURL: os.Getenv("MATTERMOST_WEBHOOK_URL"),
+		},
+		Matrix: matrixConfig{
+			Homeserver:  os.Getenv("MATRIX_HOMESERVER_URL"),
+			AccessToken: os.Getenv("MATRIX_ACCESS_TOKEN"),
+			RoomID:      os.Getenv("MATRIX_ROOM_ID"),
+		},
+		Zulip: zulipConfig{
+			Site:     os.Getenv("ZULIP_SITE"),
+			BotEmail: os.Getenv("ZULIP_BOT_EMAIL"),
+			APIKey:   os.Getenv("ZULIP_API_KEY"),
+			Stream:   os.Getenv("ZULIP_STREAM"),
+			Topic:    os.Getenv("ZULIP_TOPIC"),
+		},
+		Signal: signalConfig{
+			APIURL:     os.Getenv("SIGNAL_API_URL"),
+			Number:     os.Getenv("SIGNAL_NUMBER"),
+			Recipients: splitCSV(os.Getenv("SIGNAL_RECIPIENTS")),
+		},
+		XMPP: xmppConfig{
+			Server:     os.Getenv("XMPP_SERVER"),
+			JID:        os.Getenv("XMPP_JID"),
+			Password:   os.Getenv("XMPP_PASSWORD"),
+			Recipients: splitCSV(os.Getenv("XMPP_RECIPIENTS")),
+			CAFile:     os.Getenv("XMPP_CA_FILE"),
+		},
+		IRC: ircConfig{
+			Server:           os.Getenv("IRC_SERVER"),
+			TLS:              os.Getenv("IRC_TLS") == "true",
+			Nick:             os.Getenv("IRC_NICK"),
+			Channel:          os.Getenv("IRC_CHANNEL"),
+			NickServPassword: os.Getenv("IRC_NICKSERV_PASSWORD"),
+		},
+		MQTT: mqttConfig{
+			Broker:   os.Getenv("MQTT_BROKER"),
+			Username: os.Getenv("MQTT_USERNAME"),
+			Password: os.Getenv("MQTT_PASSWORD"),
+			Topic:    os.Getenv("MQTT_TOPIC"),
+		},
+		Kafka: kafkaConfig{
+			Brokers:  splitCSV(os.Getenv("KAFKA_BROKERS")),
+			Topic:    os.Getenv("KAFKA_TOPIC"),
+			TLS:      os.Getenv("KAFKA_TLS") == "true",
+			Username: os.Getenv("KAFKA_USERNAME"),
+			Password: os.Getenv("KAFKA_PASSWORD"),
+		},
+		NATS: natsConfig{
+			URL:       os.Getenv("NATS_URL"),
+			Subject:   os.Getenv("NATS_SUBJECT"),
+			Token:     os.Getenv("NATS_TOKEN"),
+			Username:  os.Getenv("NATS_USERNAME"),
+			Password:  os.Getenv("NATS_PASSWORD"),
+			JetStream: os.Getenv("NATS_JETSTREAM") == "true",
+		},
+		Command: os.Getenv("ALERT_COMMAND"),
+		Syslog: syslogConfig{
+			Address:  os.Getenv("SYSLOG_ADDRESS"),
+			Facility: os.Getenv("SYSLOG_FACILITY"),
+		},
+		PagerDuty: pagerDutyConfig{
+			RoutingKey: os.Getenv("PAGERDUTY_ROUTING_KEY"),
+			Severity:   os.Getenv("PAGERDUTY_SEVERITY"),
+		},
+		Opsgenie: opsgenieConfig{
+			APIKey: os.Getenv("OPSGENIE_API_KEY"),
+			APIURL: os.Getenv("OPSGENIE_API_URL"),
+		},
+		Pushover: pushoverConfig{
+			AppToken: os.Getenv("PUSHOVER_APP_TOKEN"),
+			UserKey:  os.Getenv("PUSHOVER_USER_KEY"),
+		},
+		Ntfy: ntfyConfig{
+			Server: os.Getenv("NTFY_SERVER"),
+			Topic:  os.Getenv("NTFY_TOPIC"),
+			Token:  os.Getenv("NTFY_TOKEN"),
+		},
+		Gotify: gotifyConfig{
+			URL:      os.Getenv("GOTIFY_URL"),
+			AppToken: os.Getenv("GOTIFY_APP_TOKEN"),
+		},
+		Twilio: twilioConfig{
+			AccountSID:  os.Getenv("TWILIO_ACCOUNT_SID"),
+			AuthToken:   os.Getenv("TWILIO_AUTH_TOKEN"),
+			From:        os.Getenv("TWILIO_FROM"),
+			To:          splitCSV(os.Getenv("TWILIO_TO")),
+			VoiceTo:     splitCSV(os.Getenv("TWILIO_VOICE_TO")),
+			MinSeverity: os.Getenv("TWILIO_MIN_SEVERITY"),
+		},
+		Threaded:            *threadAlertsFlag,
+		Fallback:            splitCSV(*fallbackChannelsFlag),
+		DiscordRoundThreads: *discordRoundThreadsFlag,
+		DiscordBotToken:     os.Getenv("DISCORD_BOT_TOKEN"),
+		DiscordMentions: discordMentions{
+			Roles: splitCSV(os.Getenv("DISCORD_MENTION_ROLES")),
+			Users: splitCSV(os.Getenv("DISCORD_MENTION_USERS")),
+		},
+		Webhook: webhookConfig{
+			URL:    os.Getenv("WEBHOOK_URL"),
+			Secret: os.Getenv("WEBHOOK_SECRET"),
+		},
+		Branding: branding{
+			Title:     os.Getenv("ALERT_TITLE"),
+			Footer:    os.Getenv("ALERT_FOOTER"),
+			Username:  os.Getenv("DISCORD_USERNAME"),
+			AvatarURL: os.Getenv("DISCORD_AVATAR_URL"),
+		},
+		Email: EmailConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USER"),
+			Password: os.Getenv("SMTP_PASS"),
+			From:     os.Getenv("EMAIL_FROM"),
+			To:       splitCSV(os.Getenv("EMAIL_TO")),
+			ToSeverity: map[string][]string{
+				severityInfo:     splitCSV(os.Getenv("EMAIL_TO_INFO")),
+				severityWarning:  splitCSV(os.Getenv("EMAIL_TO_WARNING")),
+				severityCritical: splitCSV(os.Getenv("EMAIL_TO_CRITICAL")),
+			},
+			Digest:             os.Getenv("EMAIL_DIGEST"),
+			TLSMode:            os.Getenv("SMTP_TLS_MODE"),
+			CAFile:             os.Getenv("SMTP_CA_FILE"),
+			InsecureSkipVerify: os.Getenv("SMTP_INSECURE_SKIP_VERIFY") == "true",
+			HTMLTemplate:       os.Getenv("EMAIL_HTML_TEMPLATE"),
+			DKIMDomain:         os.Getenv("DKIM_DOMAIN"),
+			DKIMSelector:       os.Getenv("DKIM_SELECTOR"),
+			DKIMKeyFile:        os.Getenv("DKIM_PRIVATE_KEY_FILE"),
+			OAuth2ClientID:     os.Getenv("SMTP_OAUTH2_CLIENT_ID"),
+			OAuth2ClientSecret: os.Getenv("SMTP_OAUTH2_CLIENT_SECRET"),
+			OAuth2RefreshToken: os.Getenv("SMTP_OAUTH2_REFRESH_TOKEN"),
+			OAuth2TokenURL:     os.Getenv("SMTP_OAUTH2_TOKEN_URL"),
+			OAuth2Scope:        os.Getenv("SMTP_OAUTH2_SCOPE"),
+		},
 	}
-
-	// Main RPC failover loop.
-	var currentRound uint64
-	var roundStart time.Time
-	rewardCalled := false
-	sentWarning := false
-	retryStartTime := time.Now()
-	sentInitialMonitoringAlert := false
-	for {
-		// Stop if max retry time exceeded.
-		if *maxRetryTimeFlag > 0 && time.Since(retryStartTime) > *maxRetryTimeFlag {
-			fatalMsg := fmt.Sprintf("❌ Failed to connect to any RPC after %v, giving up and shutting down reward watcher!", *maxRetryTimeFlag)
-			sendAlert(botToken, chatID, discordWebhook, emailCfg, fatalMsg, 0xFF0000)
-			log.Fatalf("%s", fatalMsg)
-		}
-
-		// Try to connect to an RPC endpoint.
-		client, usedRPC, err := connectToRPC(rpcs)
+	defaultNotifier.threads = newAlertThreads()
+	defaultNotifier.quietQueue = newQuietQueue()
+	defaultNotifier.emailDigest = newEmailDigest()
+	defaultNotifier.limiter = newAlertLimiter()
+	defaultNotifier.DedupWindow = *dedupWindowFlag
+	defaultNotifier.RateLimit = *rateLimitFlag
+	channelRateLimits, err := parseRateLimits(splitCSV(*channelRateLimitsFlag))
+	if err != nil {
+		log.Fatalf("invalid --channel-rate-limits: %v", err)
+	}
+	defaultNotifier.ChannelRateLimits = channelRateLimits
+	defaultNotifier.Quiet = quietHours{
+		Window:   *quietHoursFlag,
+		Queue:    *quietHoursQueueFlag,
+		Channels: splitCSV(*quietHoursChannelsFlag),
+	}
+	if err := defaultNotifier.Quiet.validate(); err != nil {
+		log.Fatalf("invalid --quiet-hours: %v", err)
+	}
+	if err := validateChannels(defaultNotifier.Fallback); err != nil {
+		log.Fatalf("invalid --fallback-channels: %v", err)
+	}
+	if opts.delayProgress < 0 || opts.delayProgress >= 100 {
+		log.Fatalf("invalid --delay-progress %g: must be between 0 and 100", opts.delayProgress)
+	}
+	if opts.txSigner, err = loadTxSigner(os.Getenv("TX_KEYSTORE"), os.Getenv("TX_KEYSTORE_PASSWORD"), os.Getenv("TX_PRIVATE_KEY")); err != nil {
+		log.Fatalf("invalid transaction signer: %v", err)
+	}
+	if opts.autoInitializeRound > 0 && opts.txSigner == nil {
+		log.Fatal("--auto-initialize-round requires TX_KEYSTORE or TX_PRIVATE_KEY")
+	}
+	if opts.autoReward < 0 || opts.autoReward >= 100 {
+		log.Fatalf("invalid --auto-reward %g: must be between 0 and 100", opts.autoReward)
+	}
+	if opts.autoReward > 0 && opts.txSigner == nil {
+		log.Fatal("--auto-reward requires TX_KEYSTORE or TX_PRIVATE_KEY")
+	}
+	if opts.txMaxGasPrice, err = parseGasPrice(*txMaxGasPriceFlag); err != nil {
+		log.Fatalf("invalid --tx-max-gas-price: %v", err)
+	}
+	if opts.finalCall, err = parseFinalCall(*finalCallFlag); err != nil {
+		log.Fatalf("invalid --final-call: %v", err)
+	}
+	if opts.warningStages, err = parseWarningStages(*warningStagesFlag); err != nil {
+		log.Fatalf("invalid --warning-stages: %v", err)
+	}
+	if opts.maxGasPrice, err = parseGasPrice(*maxGasPriceFlag); err != nil {
+		log.Fatalf("invalid --max-gas-price: %v", err)
+	}
+	routes, err := parseRoutes(*routesFlag)
+	if err != nil {
+		log.Fatalf("invalid --routes: %v", err)
+	}
+	defaultNotifier.Routes = routes
+	telegramTopics, err := parseTelegramTopics(os.Getenv("TELEGRAM_TOPICS"))
+	if err != nil {
+		log.Fatalf("invalid TELEGRAM_TOPICS: %v", err)
+	}
+	defaultNotifier.TelegramTopics = telegramTopics
+	if err := setLanguage(*langFlag); err != nil {
+		log.Fatalf("invalid --lang: %v", err)
+	}
+	if err := setTimezone(*tzFlag); err != nil {
+		log.Fatalf("invalid --tz: %v", err)
+	}
+	defaultNotifier.Timestamps = *timestampsFlag
+	templates, err := loadTemplates(*templatesDirFlag)
+	if err != nil {
+		log.Fatalf("invalid --templates-dir: %v", err)
+	}
+	defaultNotifier.Templates = templates
+	escalation, err := parseEscalation(*escalationFlag)
+	if err != nil {
+		log.Fatalf("invalid --escalation: %v", err)
+	}
+	defaultNotifier.Escalation = escalation
+	minSeverity, err := parseMinSeverities(splitCSV(*minSeverityFlag))
+	if err != nil {
+		log.Fatalf("invalid --min-severity: %v", err)
+	}
+	defaultNotifier.MinSeverity = minSeverity
+	if raw := os.Getenv("NOTIFY_URLS"); raw != "" {
+		// Whitespace separated, since notification URLs may contain commas.
+		targets, err := parseNotifyURLs(strings.Fields(raw))
 		if err != nil {
-			log.Printf("RPC connection failed: %v", err)
-			time.Sleep(30 * time.Second)
-			continue
+			log.Fatalf("invalid NOTIFY_URLS: %v", err)
 		}
-		log.Printf("Connected to %s", maskRPCURL(usedRPC))
+		defaultNotifier.URLs = targets
+	}
+	if err := defaultNotifier.Email.validate(); err != nil {
+		log.Fatalf("invalid email settings: %v", err)
+	}
 
-		// Load ABIs (downloaded at build time).
-		bondingABIBytes, err := os.ReadFile("ABIs/BondingManager.json")
-		if err != nil {
-			log.Fatalf("failed to read BondingManager ABI file: %v (run 'make download-abis' to download ABIs)", err)
+	// Configure mutual TLS for outbound RPC and webhook connections.
+	tlsCfg, err := loadClientTLS(*tlsClientCertFlag, *tlsClientKeyFlag, *tlsCABundleFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if tlsCfg != nil {
+		rpcClientOptions = rpcTLSOptions(tlsCfg)
+		webhookHTTPClient = tlsHTTPClient(tlsCfg, webhookHTTPClient)
+	}
+
+	// Determine the networks to watch.
+	var networks []network
+	if cfg != nil && len(cfg.Networks) > 0 {
+		if len(args) > 0 {
+			log.Fatal("Pass either networks in --config or an orchestrator address, not both")
 		}
-		bondingABI, err := abi.JSON(strings.NewReader(string(bondingABIBytes)))
+		// These flags describe a single network; silently applying them to every configured
+		// network would be wrong, so ask for the per-network field instead.
+		for _, f := range []struct{ flag, value, field string }{
+			{"reward-caller", *rewardCallerFlag, "rewardCaller"},
+			{"delegators", *delegatorsFlag, "delegators"},
+			{"node-status-url", *nodeStatusURLFlag, "nodeStatusUrl"},
+			{"rpc-list-url", *rpcListURLFlag, "rpcListUrl"},
+			{"reference-rpc", *referenceRPCFlag, "referenceRpc"},
+		} {
+			if f.value != "" {
+				log.Fatalf("--%s can't be combined with networks in --config; set %s on each network instead", f.flag, f.field)
+			}
+		}
+		networks, err = cfg.networks(defaultNotifier)
 		if err != nil {
-			log.Fatalf("failed to parse BondingManager ABI: %v", err)
+			log.Fatalf("invalid config: %v", err)
+		}
+	} else {
+		if len(args) < 1 {
+			log.Fatalf("Usage: %s <orchestrator-address[,orchestrator-address...]> [rpc1 rpc2 ...]", os.Args[0])
 		}
-		roundsABIBytes, err := os.ReadFile("ABIs/RoundsManager.json")
+		rpcs := []string{"https://arb1.arbitrum.io/rpc"}
+		if len(args) > 1 {
+			rpcs = args[1:]
+		}
+		if !defaultNotifier.configured() {
+			log.Fatal("Configure at least one alert channel, e.g. DISCORD_WEBHOOK_URL, SLACK_WEBHOOK_URL, TEAMS_WEBHOOK_URL, GOOGLE_CHAT_WEBHOOK_URL, MATTERMOST_WEBHOOK_URL, ROCKETCHAT_WEBHOOK_URL, Zulip bot settings, Signal settings, XMPP settings, IRC settings, MQTT_BROKER, KAFKA_BROKERS and KAFKA_TOPIC, NATS_URL, SYSLOG_ADDRESS, both TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID, WEBHOOK_URL, ALERT_COMMAND, NOTIFY_URLS, PAGERDUTY_ROUTING_KEY, OPSGENIE_API_KEY, both PUSHOVER_APP_TOKEN and PUSHOVER_USER_KEY, NTFY_TOPIC, both GOTIFY_URL and GOTIFY_APP_TOKEN, Twilio SMS settings, or email SMTP settings")
+		}
+		orchestrators, err := parseOrchestrators(splitCSV(args[0]), splitCSV(*rewardCallerFlag))
 		if err != nil {
-			log.Fatalf("failed to read RoundsManager ABI file: %v (run 'make download-abis' to download ABIs)", err)
+			log.Fatal(err)
 		}
-		roundsABI, err := abi.JSON(strings.NewReader(string(roundsABIBytes)))
+		delegators, err := parseAddresses(splitCSV(*delegatorsFlag))
 		if err != nil {
-			log.Fatalf("failed to parse RoundsManager ABI: %v", err)
+			log.Fatalf("invalid --delegators: %v", err)
 		}
-		rewardEvent := bondingABI.Events["Reward"]
-		newRoundEvent := roundsABI.Events["NewRound"]
+		networks = []network{{
+			Name:          "Arbitrum",
+			ChainID:       arbitrumOneChainID,
+			Orchestrators: orchestrators,
+			Delegators:    delegators,
+			NodeStatusURL: *nodeStatusURLFlag,
+			RPCListURL:    *rpcListURLFlag,
+			ReferenceRPC:  *referenceRPCFlag,
+			RPCs:          rpcs,
+			Contracts:     arbitrumOneContracts,
+			Notifier:      &defaultNotifier,
+		}}
+	}
 
-		// Subscribe to events.
-		rewardCh := make(chan types.Log)
-		rewardSub, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
-			Addresses: []common.Address{bondingManager},
-			Topics: [][]common.Hash{
-				{rewardEvent.ID},
-				{common.BytesToHash(orch.Bytes())},
-			},
-		}, rewardCh)
-		if err != nil {
-			log.Printf("Reward subscription failed: %v", err)
-			client.Close()
-			time.Sleep(5 * time.Second)
-			continue
+	for _, n := range networks {
+		registerNetworkSecrets(n)
+		for url, auth := range n.RPCAuth {
+			rpcAuth[url] = auth
 		}
-		roundCh := make(chan types.Log)
-		roundSub, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
-			Addresses: []common.Address{roundsManager},
-			Topics: [][]common.Hash{
-				{newRoundEvent.ID},
-			},
-		}, roundCh)
+	}
+
+	var exporter *exportWriter
+	if *exportCSVFlag != "" {
+		var err error
+		exporter, err = newExportWriter(*exportCSVFlag, *exportFormatFlag)
 		if err != nil {
-			log.Printf("NewRound subscription failed: %v", err)
-			rewardSub.Unsubscribe()
-			client.Close()
-			time.Sleep(5 * time.Second)
-			continue
+			log.Fatal(err)
 		}
+	}
 
-		// Round and Reward monitoring loop.
-		log.Println("Monitoring started...")
-		if !sentInitialMonitoringAlert {
-			monitoringMsg := fmt.Sprintf(
-				"🟢 Livepeer Reward watcher monitoring orchestrator [%s](https://explorer.livepeer.org/accounts/%s/delegating) on Arbitrum.",
-				orch.Hex(), strings.ToLower(orch.Hex()))
-			sendAlert(botToken, chatID, discordWebhook, emailCfg, monitoringMsg, 0x00FF00)
-			sentInitialMonitoringAlert = true
-		} else {
-			recoveryMsg := fmt.Sprintf("✅ RPC connection restored to %s, resuming monitoring.", maskRPCURL(usedRPC))
-			if *enableRPCAlertsFlag {
-				sendAlert(botToken, chatID, discordWebhook, emailCfg, recoveryMsg, 0x00FF00)
-			}
+	// Load ABIs (downloaded at build time).
+	abis, err := loadContractABIs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *announceReleasesFlag {
+		notifiers := make([]*notifier, 0, len(networks))
+		for _, n := range networks {
+			notifiers = append(notifiers, n.Notifier)
 		}
-		ticker := time.NewTicker(*checkIntervalFlag)
-	monitorLoop:
-		for {
-			select {
-			case err := <-rewardSub.Err():
-				log.Printf("Reward subscription error: %v", err)
-				if *enableRPCAlertsFlag {
-					sendAlert(botToken, chatID, discordWebhook, emailCfg, fmt.Sprintf("⚠️ Reward subscription error: %v", err), 0xFF0000)
-				}
-				break monitorLoop
-			case err := <-roundSub.Err():
-				log.Printf("NewRound subscription error: %v", err)
-				if *enableRPCAlertsFlag {
-					sendAlert(botToken, chatID, discordWebhook, emailCfg, fmt.Sprintf("⚠️ NewRound subscription error: %v", err), 0xFF0000)
-				}
-				break monitorLoop
-			case vLog := <-rewardCh:
-				// Reward called for this round.
-				rewardCalled = true
-				address := strings.ToLower(orch.Hex())
-				txHash := vLog.TxHash.Hex()
-				alertMsg := fmt.Sprintf(
-					"✅ Reward called for [%s](https://explorer.livepeer.org/accounts/%s/delegating) in round %d at block %d, [tx %s](https://arbiscan.io/tx/%s).",
-					address, address, currentRound, vLog.BlockNumber, txHash, txHash)
-				log.Println(alertMsg)
-				if !*disableSuccessAlertsFlag {
-					sendAlert(botToken, chatID, discordWebhook, emailCfg, alertMsg, 0x00FF00)
-				}
-			case vLog := <-roundCh:
-				// New round started.
-				var roundNum uint64
-				if len(vLog.Topics) > 1 {
-					roundNum = vLog.Topics[1].Big().Uint64()
-				}
-				currentRound = roundNum
-				roundStart = time.Now()
-				rewardCalled = false
-				sentWarning = false
-				log.Printf("New round %d started", currentRound)
-				if !*disableRoundAlertsFlag {
-					newRoundMsg := fmt.Sprintf("🔄 New round %d started.", currentRound)
-					sendAlert(botToken, chatID, discordWebhook, emailCfg, newRoundMsg, 0x0099FF)
-				}
-			case <-ticker.C:
-				if !rewardCalled && !roundStart.IsZero() {
-					elapsed := time.Since(roundStart)
-					if elapsed >= *delayFlag {
-						if *repeatFlag || !sentWarning {
-							address := strings.ToLower(orch.Hex())
-							alertMsg := fmt.Sprintf(
-								"❌ No reward called for [%s](https://explorer.livepeer.org/accounts/%s/delegating) in round %d after %s.",
-								address, address, currentRound, delayFlag.String())
-							log.Println(alertMsg)
-							sendAlert(botToken, chatID, discordWebhook, emailCfg, alertMsg, 0xFF0000)
-							sentWarning = true
-						}
-					}
-				}
-			}
+		go runReleaseAnnouncer(*releaseCheckIntervalFlag, notifiers)
+	}
+
+	var state *stateStore
+	if *stateFileFlag != "" {
+		if state, err = loadStateStore(*stateFileFlag); err != nil {
+			log.Fatal(err)
 		}
+	}
+
+	svc := &services{
+		state:    state,
+		abis:     abis,
+		exporter: exporter,
+		status:   newStatusBoard(*statusFileFlag),
+	}
 
-		// Cleanup state before reconnecting.
-		ticker.Stop()
-		rewardSub.Unsubscribe()
-		roundSub.Unsubscribe()
-		client.Close()
-		time.Sleep(5 * time.Second) // Brief pause before trying to reconnect
-		retryStartTime = time.Now() // Start retry timer
+	// Run a watcher per network.
+	watchers := make([]*watcher, 0, len(networks))
+	for _, n := range networks {
+		if opts.quorum > len(n.RPCs) && n.RPCListURL == "" {
+			log.Fatalf("--quorum %d needs at least as many RPCs, %s has %d", opts.quorum, n.Name, len(n.RPCs))
+		}
+		w := newWatcher(&opts, n, svc)
+		if opts.autoReward > 0 {
+			w.logAutoRewardCoverage()
+		}
+		watchers = append(watchers, w)
+	}
+	handleDumpSignal(watchers, svc.status)
+	if *listenFlag == "" {
+		*listenFlag = *metricsAddrFlag
+	}
+	if *listenFlag != "" {
+		var actions http.Handler
+		if secret := os.Getenv("ACTION_API_SECRET"); secret != "" {
+			registerSecret(secret)
+			actions = actionHandler(secret, watchers, svc.status)
+		}
+		startHTTPServer(*listenFlag, svc.status, actions)
+	}
+	if token := os.Getenv("DISCORD_BOT_TOKEN"); token != "" {
+		registerSecret(token)
+		go runDiscordBot(token, os.Getenv("DISCORD_GUILD_ID"), watchers, svc.status)
+	}
+	if *telegramCommandsFlag {
+		for token, botWatchers := range telegramBots(watchers) {
+			go runTelegramBot(token, botWatchers, svc.status)
+		}
+	}
+	var wg sync.WaitGroup
+	for _, w := range watchers {
+		wg.Add(1)
+		go func(w *watcher) {
+			defer wg.Done()
+			w.run()
+		}(w)
 	}
+	wg.Wait()
 }
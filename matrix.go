@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// matrixConfig configures the Matrix alert channel.
+type matrixConfig struct {
+	Homeserver  string `json:"homeserver"`
+	AccessToken string `json:"accessToken"`
+	RoomID      string `json:"roomId"`
+}
+
+func (c matrixConfig) complete() bool {
+	return c.Homeserver != "" && c.AccessToken != "" && c.RoomID != ""
+}
+
+// sendMatrixAlert sends a message to a Matrix room, with an HTML body so links stay clickable.
+func sendMatrixAlert(cfg matrixConfig, message string) error {
+	payload := map[string]string{
+		"msgtype":        "m.text",
+		"body":           markdownLinkRe.ReplaceAllString(message, "$1 ($2)"),
+		"format":         "org.matrix.custom.html",
+		"formatted_body": markdownBodyToHTML(message),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	txnID := fmt.Sprintf("reward-watcher-%d", time.Now().UnixNano())
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(cfg.Homeserver, "/"), url.PathEscape(cfg.RoomID), txnID)
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.AccessToken)
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("matrix homeserver returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
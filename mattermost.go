@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// mattermostConfig configures the Mattermost alert channel.
+type mattermostConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	// Channels overrides the webhook's default channel per severity (critical, warning or info).
+	Channels map[string]string `json:"channels"`
+}
+
+// sendMattermostAlert posts an alert to a Mattermost incoming webhook as a colored attachment.
+// Mattermost renders the markdown links of the message as is.
+func sendMattermostAlert(cfg mattermostConfig, message string, color int, severity string, brand branding) error {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	attachment := map[string]interface{}{
+		"fallback": message,
+		"color":    fmt.Sprintf("#%06X", color),
+		"title":    title,
+		"text":     message,
+	}
+	if brand.Footer != "" {
+		attachment["footer"] = brand.Footer
+	}
+	payload := map[string]interface{}{
+		"attachments": []map[string]interface{}{attachment},
+	}
+	if channel := cfg.Channels[severity]; channel != "" {
+		payload["channel"] = channel
+	}
+	if brand.Username != "" {
+		payload["username"] = brand.Username
+	}
+	if brand.AvatarURL != "" {
+		payload["icon_url"] = brand.AvatarURL
+	}
+	if err := postJSON(cfg.WebhookURL, payload); err != nil {
+		return fmt.Errorf("mattermost webhook: %v", err)
+	}
+	return nil
+}
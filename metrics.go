@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// counterSet holds the Prometheus counters of the process, keyed by metric and label set.
+type counterSet struct {
+	mu     sync.Mutex
+	help   map[string]string
+	values map[string]map[string]float64
+}
+
+// metrics collects the counters exposed on /metrics.
+var metrics = &counterSet{
+	help: map[string]string{
+		"reward_watcher_alerts_total":              "Alerts delivered per channel and result.",
+		"reward_watcher_alerts_suppressed_total":   "Alerts dropped as duplicates, by the global rate limit or while muted.",
+		"reward_watcher_rpc_reconnects_total":      "RPC reconnections after a lost connection.",
+		"reward_watcher_subscription_errors_total": "Errors reported by event subscriptions.",
+	},
+	values: make(map[string]map[string]float64),
+}
+
+// inc increments a counter. labels are name/value pairs.
+func (c *counterSet) inc(name string, labels ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values[name] == nil {
+		c.values[name] = make(map[string]float64)
+	}
+	c.values[name][formatLabels(labels...)]++
+}
+
+// write renders the counters in the Prometheus text format.
+func (c *counterSet) write(out io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.help))
+	for name := range c.help {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s counter\n", name, c.help[name], name)
+		series := make([]string, 0, len(c.values[name]))
+		for labels := range c.values[name] {
+			series = append(series, labels)
+		}
+		sort.Strings(series)
+		for _, labels := range series {
+			fmt.Fprintf(out, "%s%s %g\n", name, labels, c.values[name][labels])
+		}
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels renders name/value pairs as a Prometheus label set.
+func formatLabels(labels ...string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// writeGauges renders the gauges derived from the latest watcher statuses.
+func writeGauges(out io.Writer, status *statusBoard) {
+	status.mu.Lock()
+	statuses := make([]watcherStatus, 0, len(status.statuses))
+	for _, s := range status.statuses {
+		statuses = append(statuses, s)
+	}
+	status.mu.Unlock()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Network < statuses[j].Network })
+
+	gauge := func(name, help string, value func(s watcherStatus) float64) {
+		fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, s := range statuses {
+			fmt.Fprintf(out, "%s%s %g\n", name, formatLabels("network", s.Network), value(s))
+		}
+	}
+	gauge("reward_watcher_current_round", "Current Livepeer round.", func(s watcherStatus) float64 {
+		return float64(s.CurrentRound)
+	})
+	gauge("reward_watcher_seconds_since_round_start", "Seconds since the current round started.", func(s watcherStatus) float64 {
+		if s.RoundStart.IsZero() {
+			return 0
+		}
+		return time.Since(s.RoundStart).Seconds()
+	})
+	gauge("reward_watcher_last_processed_block", "Newest block seen through the event subscriptions.", func(s watcherStatus) float64 {
+		return float64(s.LastProcessedBlock)
+	})
+	gauge("reward_watcher_rpc_connected", "Whether the watcher is connected to an RPC endpoint.", func(s watcherStatus) float64 {
+		if s.ConnectedRPC == "" {
+			return 0
+		}
+		return 1
+	})
+
+	fmt.Fprintf(out, "# HELP reward_watcher_reward_called Whether reward was called in the current round.\n# TYPE reward_watcher_reward_called gauge\n")
+	for _, s := range statuses {
+		for _, o := range s.Orchestrators {
+			called := 0
+			if o.RewardCalled {
+				called = 1
+			}
+			fmt.Fprintf(out, "reward_watcher_reward_called%s %d\n", formatLabels("network", s.Network, "orchestrator", o.Address), called)
+		}
+	}
+}
+
+// startHTTPServer serves the watcher status and Prometheus metrics on addr in the background,
+// and the action API on /actions when actions is set.
+func startHTTPServer(addr string, status *statusBoard, actions http.Handler) {
+	mux := http.NewServeMux()
+	if actions != nil {
+		mux.Handle("/actions", actions)
+	}
+	mux.HandleFunc("/status", func(rw http.ResponseWriter, r *http.Request) {
+		status.mu.Lock()
+		data := status.snapshotJSON()
+		connected := true
+		for _, s := range status.statuses {
+			connected = connected && s.ConnectedRPC != ""
+		}
+		status.mu.Unlock()
+		rw.Header().Set("Content-Type", "application/json")
+		if !connected {
+			// Lets plain HTTP health checks detect a watcher that lost its RPC connection.
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		rw.Write(data)
+	})
+	mux.HandleFunc("/metrics", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeGauges(rw, status)
+		metrics.write(rw)
+	})
+	go func() {
+		log.Printf("Serving status and metrics on %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+}
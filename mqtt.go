@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const defaultMQTTTopic = "livepeer/reward-watcher"
+
+// mqttConfig configures the MQTT event sink.
+type mqttConfig struct {
+	// Broker is the broker URL: tcp://host:1883, or ssl:// (also tls://, mqtts://) for TLS.
+	Broker   string `json:"broker"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Topic is the topic prefix; events are published to <topic>/<kind>/<type>.
+	Topic    string `json:"topic"`
+	ClientID string `json:"clientId"`
+	// Retain publishes events as retained messages, so new subscribers get the latest state.
+	Retain bool `json:"retain"`
+}
+
+// mqttBrokerURL returns the broker URL with the default port of its scheme, which the client
+// requires.
+func mqttBrokerURL(broker string) (string, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return "", fmt.Errorf("invalid broker URL: %v", err)
+	}
+	var port string
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		port = "1883"
+	case "ssl", "tls", "mqtts":
+		port = "8883"
+	default:
+		return "", fmt.Errorf("unsupported broker scheme %q", u.Scheme)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), port)
+	}
+	return u.String(), nil
+}
+
+// mqttConn is a connection to an MQTT broker.
+type mqttConn struct {
+	client mqtt.Client
+	cfg    mqttConfig
+}
+
+// dialMQTT connects to the broker. Reconnecting is left to the sink publisher.
+func dialMQTT(cfg mqttConfig) (*mqttConn, error) {
+	broker, err := mqttBrokerURL(cfg.Broker)
+	if err != nil {
+		return nil, err
+	}
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = fmt.Sprintf("reward-watcher-%d", time.Now().UnixNano())
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(clientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetConnectTimeout(10 * time.Second).
+		SetWriteTimeout(10 * time.Second).
+		SetAutoReconnect(false)
+	client := mqtt.NewClient(opts)
+	if err := mqttWait(client.Connect()); err != nil {
+		return nil, err
+	}
+	return &mqttConn{client: client, cfg: cfg}, nil
+}
+
+// mqttWait waits for an MQTT operation to complete.
+func mqttWait(token mqtt.Token) error {
+	if !token.WaitTimeout(30 * time.Second) {
+		return fmt.Errorf("timed out waiting for the broker")
+	}
+	return token.Error()
+}
+
+// publish publishes an event as JSON to <topic>/<kind>/<type> with QoS 1.
+func (c *mqttConn) publish(e event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	prefix := c.cfg.Topic
+	if prefix == "" {
+		prefix = defaultMQTTTopic
+	}
+	topic := strings.TrimRight(prefix, "/") + "/" + e.Kind + "/" + e.Type
+	return mqttWait(c.client.Publish(topic, 1, c.cfg.Retain, payload))
+}
+
+func (c *mqttConn) Close() error {
+	c.client.Disconnect(250)
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const defaultNATSSubject = "livepeer.reward-watcher"
+
+// natsConfig configures the NATS event sink.
+type natsConfig struct {
+	// URL is the server URL: nats://host:4222, or tls://host:4222 to require TLS.
+	URL string `json:"url"`
+	// Subject is the subject prefix; events are published to <subject>.<kind>.<type>.
+	Subject  string `json:"subject"`
+	Token    string `json:"token"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// JetStream waits for the stream capturing the subject to acknowledge every event.
+	JetStream bool `json:"jetStream"`
+}
+
+// natsConn is a connection to a NATS server.
+type natsConn struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+	cfg  natsConfig
+}
+
+// dialNATS connects and authenticates to the NATS server. Reconnecting is left to the sink
+// publisher.
+func dialNATS(cfg natsConfig) (*natsConn, error) {
+	opts := []nats.Option{
+		nats.Name("livepeer-reward-watcher"),
+		nats.Timeout(10 * time.Second),
+		nats.NoReconnect(),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, nats.Token(cfg.Token))
+	}
+	if cfg.Username != "" {
+		opts = append(opts, nats.UserInfo(cfg.Username, cfg.Password))
+	}
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := &natsConn{conn: conn, cfg: cfg}
+	if cfg.JetStream {
+		if c.js, err = conn.JetStream(nats.MaxWait(10 * time.Second)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// natsSubject returns the subject of an event: <subject>.<kind>.<type>.
+func natsSubject(prefix string, e event) string {
+	if prefix == "" {
+		prefix = defaultNATSSubject
+	}
+	return strings.TrimRight(prefix, ".") + "." + e.Kind + "." + e.Type
+}
+
+// publish publishes an event as JSON. With JetStream enabled it waits for the stream's
+// acknowledgement, otherwise for the server to process the message.
+func (c *natsConn) publish(e event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	subject := natsSubject(c.cfg.Subject, e)
+	if c.js == nil {
+		if err := c.conn.Publish(subject, payload); err != nil {
+			return err
+		}
+		return c.conn.FlushTimeout(10 * time.Second)
+	}
+	if _, err := c.js.Publish(subject, payload); err != nil {
+		if errors.Is(err, nats.ErrNoStreamResponse) {
+			return fmt.Errorf("no JetStream stream captures subject %s", subject)
+		}
+		return fmt.Errorf("JetStream rejected the event: %v", err)
+	}
+	return nil
+}
+
+func (c *natsConn) Close() error {
+	c.conn.Close()
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestNATSSubject(t *testing.T) {
+	tests := []struct {
+		prefix string
+		e      event
+		want   string
+	}{
+		{"", event{Kind: "chain", Type: "Reward"}, "livepeer.reward-watcher.chain.Reward"},
+		{"ops.livepeer", event{Kind: "alert", Type: "reward_missed"}, "ops.livepeer.alert.reward_missed"},
+		{"ops.", event{Kind: "lifecycle", Type: "rpc_connected"}, "ops.lifecycle.rpc_connected"},
+	}
+	for _, tt := range tests {
+		if got := natsSubject(tt.prefix, tt.e); got != tt.want {
+			t.Errorf("natsSubject(%q, %s/%s) = %q, want %q", tt.prefix, tt.e.Kind, tt.e.Type, got, tt.want)
+		}
+	}
+}
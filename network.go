@@ -0,0 +1,50 @@
+package main
+
+import "github.com/ethereum/go-ethereum/common"
+
+// contracts holds the Livepeer protocol contract addresses of a network.
+type contracts struct {
+	BondingManager  common.Address
+	RoundsManager   common.Address
+	TicketBroker    common.Address
+	ServiceRegistry common.Address
+	Controller      common.Address
+}
+
+// arbitrumOneContracts are the Livepeer contracts deployed on Arbitrum One.
+var arbitrumOneContracts = contracts{
+	// BondingManager contract: https://arbiscan.io/address/0x35Bcf3c30594191d53231E4FF333E8A770453e40
+	BondingManager: common.HexToAddress("0x35Bcf3c30594191d53231E4FF333E8A770453e40"),
+	// RoundsManager contract: https://arbiscan.io/address/0xdd6f56DcC28D3F5f27084381fE8Df634985cc39f
+	RoundsManager: common.HexToAddress("0xdd6f56DcC28D3F5f27084381fE8Df634985cc39f"),
+	// TicketBroker contract: https://arbiscan.io/address/0xa8bB618B1520E284046F3dFc448851A1Ff26e41B
+	TicketBroker: common.HexToAddress("0xa8bB618B1520E284046F3dFc448851A1Ff26e41B"),
+	// ServiceRegistry contract: https://arbiscan.io/address/0xC92d3A360b8f9e083bA64DE15d95Cf8180897431
+	ServiceRegistry: common.HexToAddress("0xC92d3A360b8f9e083bA64DE15d95Cf8180897431"),
+	// Controller contract: https://arbiscan.io/address/0xD8E8328501E9645d16Cf49539efC04f734606ee4
+	Controller: common.HexToAddress("0xD8E8328501E9645d16Cf49539efC04f734606ee4"),
+}
+
+// arbitrumOneChainID is the chain ID of Arbitrum One.
+const arbitrumOneChainID = 42161
+
+// network is a chain context watched by a single watcher.
+type network struct {
+	Name    string
+	ChainID uint64
+	// Orchestrators are the orchestrators watched on the network.
+	Orchestrators []orchestratorConfig
+	// Delegators are accounts whose earnings claim status is watched (optional).
+	Delegators []common.Address
+	RPCs       []string
+	// RPCListURL is a remote endpoint list that periodically replaces RPCs (optional).
+	RPCListURL string
+	// ReferenceRPC is compared against to detect a lagging chain head (optional).
+	ReferenceRPC string
+	// RPCAuth holds the credentials of RPC endpoints, keyed by URL (optional).
+	RPCAuth map[string]rpcAuthConfig
+	// NodeStatusURL is the go-livepeer status endpoint used for version checks (optional).
+	NodeStatusURL string
+	Contracts     contracts
+	Notifier      *notifier
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// nodeStatus contains the fields we use from the go-livepeer /status endpoint.
+type nodeStatus struct {
+	Version string `json:"Version"`
+}
+
+// nodeVersionState tracks the last version alert to avoid repeating it every check.
+type nodeVersionState struct {
+	alertedFor string
+}
+
+// fetchNodeVersion queries the go-livepeer status endpoint for the running version.
+func fetchNodeVersion(statusURL string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(statusURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status request failed: HTTP %d", resp.StatusCode)
+	}
+	var status nodeStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return "", fmt.Errorf("failed to parse node status: %v", err)
+	}
+	if status.Version == "" {
+		return "", fmt.Errorf("node status contains no version")
+	}
+	return status.Version, nil
+}
+
+// checkNodeVersion compares the node's go-livepeer version against the latest GitHub releases and
+// alerts when it is too many releases behind or misses a security release.
+func (w *watcher) checkNodeVersion() {
+	version, err := fetchNodeVersion(w.net.NodeStatusURL)
+	if err != nil {
+		w.log.Printf("failed to fetch go-livepeer version from node: %v", err)
+		return
+	}
+	current, ok := parseVersion(version)
+	if !ok {
+		w.log.Printf("unrecognized go-livepeer version %q", version)
+		return
+	}
+	releases, err := fetchGoLivepeerReleases()
+	if err != nil {
+		w.log.Printf("failed to fetch go-livepeer releases: %v", err)
+		return
+	}
+	var newer []githubRelease
+	var security []string
+	for _, r := range releases {
+		v, ok := parseVersion(r.TagName)
+		if !ok || compareVersions(v, current) <= 0 {
+			continue
+		}
+		newer = append(newer, r)
+		if r.isSecurityRelease() {
+			security = append(security, r.TagName)
+		}
+	}
+	if len(newer) == 0 {
+		w.log.Printf("go-livepeer %s is up to date", version)
+		return
+	}
+	latest := newer[0]
+	alertKey := version + "->" + latest.TagName
+	if w.nodeVersion.alertedFor == alertKey {
+		return
+	}
+	var msg string
+	switch {
+	case len(security) > 0:
+		msg = fmt.Sprintf(
+			tr("❌ Orchestrator node runs go-livepeer %s and misses security release(s) %s. Latest is [%s](%s)."),
+			version, strings.Join(security, ", "), latest.TagName, latest.HTMLURL)
+	case len(newer) > w.opts.maxReleasesBehind:
+		msg = fmt.Sprintf(
+			tr("⚠️ Orchestrator node runs go-livepeer %s, %d release(s) behind the latest [%s](%s)."),
+			version, len(newer), latest.TagName, latest.HTMLURL)
+	default:
+		w.log.Printf("go-livepeer %s is %d release(s) behind %s", version, len(newer), latest.TagName)
+		return
+	}
+	w.log.Println(msg)
+	w.alert("node_outdated", msg, 0xFFA500)
+	w.nodeVersion.alertedFor = alertKey
+}
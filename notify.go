@@ -0,0 +1,976 @@
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// discordThread selects the thread a Discord webhook message is posted in. Set ID to post in an
+// existing thread, or Name to start a new one (forum channels only). The zero value posts to the channel.
+type discordThread struct {
+	ID   string
+	Name string
+}
+
+// discordMentions are the roles and users pinged by critical Discord alerts.
+type discordMentions struct {
+	Roles []string `json:"roles"`
+	Users []string `json:"users"`
+}
+
+// content returns the message content that pings the mentioned roles and users.
+func (m discordMentions) content() string {
+	var parts []string
+	for _, id := range m.Roles {
+		parts = append(parts, "<@&"+id+">")
+	}
+	for _, id := range m.Users {
+		parts = append(parts, "<@"+id+">")
+	}
+	return strings.Join(parts, " ")
+}
+
+// nonNil returns an empty list for nil, so it is encoded as [] instead of null.
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// Default alert titles, used when no branding title is configured.
+const (
+	defaultDiscordTitle = "Livepeer Reward watcher Alert"
+	defaultEmailSubject = "Livepeer Reward Watcher Alert"
+)
+
+// branding customizes how alerts present themselves, to tell watchers apart in shared channels.
+type branding struct {
+	Title     string `json:"title"`
+	Footer    string `json:"footer"`
+	Username  string `json:"username"`  // Discord webhook username override.
+	AvatarURL string `json:"avatarUrl"` // Discord webhook avatar override.
+}
+
+// sendDiscordAlert sends a message to a Discord channel using a webhook, with color, and returns
+// the ID of the channel or thread the message was posted in. Only the given mentions can ping.
+func sendDiscordAlert(webhookURL, message string, color int, thread discordThread, mentions discordMentions, brand branding) (string, error) {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	embed := map[string]interface{}{
+		"title":       title,
+		"description": message,
+		"color":       color,
+	}
+	if brand.Footer != "" {
+		embed["footer"] = map[string]string{"text": brand.Footer}
+	}
+	payload := map[string]interface{}{
+		"embeds": []map[string]interface{}{embed},
+		// Never ping for mentions that appear in the alert text itself.
+		"allowed_mentions": map[string]interface{}{
+			"parse": []string{},
+			"roles": nonNil(mentions.Roles),
+			"users": nonNil(mentions.Users),
+		},
+	}
+	if content := mentions.content(); content != "" {
+		payload["content"] = content
+	}
+	if brand.Username != "" {
+		payload["username"] = brand.Username
+	}
+	if brand.AvatarURL != "" {
+		payload["avatar_url"] = brand.AvatarURL
+	}
+	if thread.ID == "" && thread.Name != "" {
+		payload["thread_name"] = thread.Name
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("wait", "true")
+	if thread.ID != "" {
+		q.Set("thread_id", thread.ID)
+	}
+	u.RawQuery = q.Encode()
+	body, _ := json.Marshal(payload)
+	resp, err := http.Post(u.String(), "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		ChannelID string `json:"channel_id"`
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("discord webhook returned HTTP %d", resp.StatusCode)
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result.ChannelID, nil
+}
+
+type EmailConfig struct {
+	Host     string   `json:"host"`
+	Port     string   `json:"port"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	// ToSeverity adds recipients for alerts of a severity, e.g. an ops alias for critical alerts.
+	ToSeverity map[string][]string `json:"toSeverity"`
+	// Digest batches informational alerts into one email per interval, e.g. "1h" or "24h".
+	Digest string `json:"digest"`
+	// TLSMode is "auto" (STARTTLS when offered, implicit TLS on port 465), "starttls", "tls"
+	// (implicit TLS) or "none".
+	TLSMode            string `json:"tlsMode"`
+	CAFile             string `json:"caFile"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+	// HTMLTemplate is an html/template file the alerts are rendered into.
+	HTMLTemplate string `json:"htmlTemplate"`
+	// DKIMDomain, DKIMSelector and DKIMKeyFile sign the emails with DKIM when all are set.
+	DKIMDomain   string `json:"dkimDomain"`
+	DKIMSelector string `json:"dkimSelector"`
+	DKIMKeyFile  string `json:"dkimKeyFile"`
+	// OAuth2 authenticates with XOAUTH2 and a refresh token instead of a password, for Gmail and
+	// Office 365. OAuth2TokenURL defaults to the provider's endpoint for their SMTP hosts.
+	OAuth2ClientID     string `json:"oauth2ClientId"`
+	OAuth2ClientSecret string `json:"oauth2ClientSecret"`
+	OAuth2RefreshToken string `json:"oauth2RefreshToken"`
+	OAuth2TokenURL     string `json:"oauth2TokenUrl"`
+	OAuth2Scope        string `json:"oauth2Scope"`
+
+	template *htmltemplate.Template
+	dkimKey  crypto.Signer
+}
+
+// SMTP TLS modes.
+const (
+	smtpTLSAuto     = "auto"
+	smtpTLSStartTLS = "starttls"
+	smtpTLSImplicit = "tls"
+	smtpTLSNone     = "none"
+)
+
+func (c EmailConfig) complete() bool {
+	hasRecipients := len(c.To) > 0
+	for _, to := range c.ToSeverity {
+		hasRecipients = hasRecipients || len(to) > 0
+	}
+	return c.Host != "" && c.From != "" && hasRecipients && c.Username != "" && (c.Password != "" || c.usesOAuth2())
+}
+
+// recipients returns the recipients of an alert of the given severity: the recipients of all
+// alerts plus those of its severity.
+func (c EmailConfig) recipients(severity string) []string {
+	out := append([]string(nil), c.To...)
+	for _, to := range c.ToSeverity[severity] {
+		if !slices.Contains(out, to) {
+			out = append(out, to)
+		}
+	}
+	return out
+}
+
+// tlsMode returns the effective TLS mode of the SMTP connection.
+func (c EmailConfig) tlsMode() (string, error) {
+	switch mode := strings.ToLower(c.TLSMode); mode {
+	case "", smtpTLSAuto:
+		if c.Port == "465" {
+			return smtpTLSImplicit, nil
+		}
+		return smtpTLSAuto, nil
+	case smtpTLSStartTLS, smtpTLSImplicit, smtpTLSNone:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid SMTP TLS mode %q, expected auto, starttls, tls or none", c.TLSMode)
+	}
+}
+
+// validate checks the email settings and fills in the default port for the TLS mode.
+func (c *EmailConfig) validate() error {
+	if _, err := c.digestInterval(); err != nil {
+		return err
+	}
+	for severity := range c.ToSeverity {
+		if _, ok := severityRanks[severity]; !ok {
+			return fmt.Errorf("invalid email recipient severity %q, expected info, warning or critical", severity)
+		}
+	}
+	mode, err := c.tlsMode()
+	if err != nil {
+		return err
+	}
+	if c.Host != "" && c.Port == "" {
+		c.Port = "587"
+		if mode == smtpTLSImplicit {
+			c.Port = "465"
+		}
+	}
+	if c.HTMLTemplate != "" {
+		if c.template, err = loadEmailTemplate(c.HTMLTemplate); err != nil {
+			return err
+		}
+	}
+	if c.usesOAuth2() && (c.OAuth2ClientID == "" || c.oauth2TokenURL() == "") {
+		return fmt.Errorf("SMTP OAuth2 needs a client ID and, for hosts other than Gmail and Office 365, a token URL")
+	}
+	if c.DKIMDomain != "" || c.DKIMSelector != "" || c.DKIMKeyFile != "" {
+		if c.DKIMDomain == "" || c.DKIMSelector == "" || c.DKIMKeyFile == "" {
+			return fmt.Errorf("DKIM signing needs a domain, a selector and a private key")
+		}
+		if c.dkimKey, err = loadDKIMKey(c.DKIMKeyFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS config for the SMTP server, trusting the optional CA bundle.
+func (c EmailConfig) tlsConfig() (*tls.Config, error) {
+	cfg, err := loadClientTLS("", "", c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	cfg.ServerName = c.Host
+	// For internal relays with self-signed certificates only.
+	cfg.InsecureSkipVerify = c.InsecureSkipVerify
+	return cfg, nil
+}
+
+// emailMessageID returns a unique Message-ID in the domain of the sender address.
+func emailMessageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if _, d, ok := strings.Cut(addr.Address, "@"); ok {
+			domain = d
+		}
+	}
+	random := make([]byte, 8)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%x@%s>", time.Now().UnixNano(), random, domain)
+}
+
+// sendEmailAlert sends an email with plain text and HTML alternatives using SMTP.
+func sendEmailAlert(cfg EmailConfig, to []string, subject, text, html string) error {
+	if !cfg.complete() {
+		return fmt.Errorf("email config is incomplete")
+	}
+	mode, err := cfg.tlsMode()
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(cfg.Host, cfg.Port)
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	if mode == smtpTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if mode == smtpTLSAuto || mode == smtpTLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		} else if mode == smtpTLSStartTLS {
+			return fmt.Errorf("SMTP server %s does not offer STARTTLS", cfg.Host)
+		}
+	}
+	if cfg.usesOAuth2() {
+		token, err := cfg.oauth2AccessToken()
+		if err != nil {
+			return err
+		}
+		if err := c.Auth(xoauth2Auth{cfg.Username, token}); err != nil {
+			return err
+		}
+	} else if cfg.Username != "" {
+		// PlainAuth refuses to send the password over an unencrypted connection to a remote host.
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return err
+		}
+	}
+	content, contentType, err := multipartAlternative(text, html)
+	if err != nil {
+		return err
+	}
+	headers := [][2]string{
+		{"From", cfg.From},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", emailMessageID(cfg.From)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType},
+	}
+	if cfg.dkimKey != nil {
+		signature, err := dkimSign(cfg, headers, content)
+		if err != nil {
+			return err
+		}
+		headers = append([][2]string{{"DKIM-Signature", signature}}, headers...)
+	}
+	var body strings.Builder
+	for _, h := range headers {
+		body.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	body.WriteString("\r\n" + content)
+	if err := c.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(body.String())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+var alertHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// postJSON posts payload as JSON and fails on non-2xx responses.
+func postJSON(url string, payload interface{}) error {
+	return postJSONWithHeaders(url, nil, payload)
+}
+
+// postJSONWithHeaders posts payload as JSON with extra request headers, e.g. for authentication.
+func postJSONWithHeaders(url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notifier holds the alert channels of a watcher.
+type notifier struct {
+	TelegramBotToken  string
+	TelegramChatID    string
+	TelegramTopics    map[string]int // Forum topics (message_thread_id) per alert type.
+	DiscordWebhook    string
+	SlackWebhook      string
+	TeamsWebhook      string
+	GoogleChatWebhook string
+	Mattermost        mattermostConfig
+	Matrix            matrixConfig
+	RocketChatWebhook string
+	Zulip             zulipConfig
+	Signal            signalConfig
+	XMPP              xmppConfig
+	IRC               ircConfig
+	MQTT              mqttConfig
+	Kafka             kafkaConfig
+	NATS              natsConfig
+	Syslog            syslogConfig
+	PagerDuty         pagerDutyConfig
+	Opsgenie          opsgenieConfig
+	Pushover          pushoverConfig
+	Ntfy              ntfyConfig
+	Gotify            gotifyConfig
+	Twilio            twilioConfig
+	Email             EmailConfig
+	Branding          branding
+	Webhook           webhookConfig
+	// Command is a shell command run for every alert.
+	Command string
+	// URLs are alert channels configured through Shoutrrr-style notification URLs.
+	URLs []notifyTarget
+	// Fallback lists channels (e.g. "email") that only receive alerts when every other channel fails.
+	// Unacknowledged alerts don't trigger them, as the channels don't report acknowledgements.
+	Fallback []string
+	// Routes maps alert types to the channels that receive them; see parseRoutes.
+	Routes map[string][]string
+	// MinSeverity maps channels to the lowest severity they receive; see parseMinSeverities.
+	MinSeverity map[string]string
+	// Quiet holds back non-critical alerts during a daily window.
+	Quiet      quietHours
+	quietQueue *quietQueue
+	// emailDigest holds the informational alerts for the next email digest.
+	emailDigest *emailDigest
+	// Templates override the built-in alert texts per alert type.
+	Templates alertTemplates
+	// Timestamps adds the alert time and the round start time to alerts.
+	Timestamps bool
+	// Escalation sends the missed-reward alert to more channels the longer reward stays missing.
+	Escalation []escalationStage
+	// DedupWindow collapses identical alerts sent within this window into one (0 = disabled).
+	DedupWindow time.Duration
+	// RateLimit caps the alerts sent per minute, and ChannelRateLimits the alerts per minute of
+	// single channels (0 = unlimited).
+	RateLimit         int
+	ChannelRateLimits map[string]int
+	limiter           *alertLimiter
+	// Label is prefixed to every alert to tell apart watchers sharing a channel (e.g. the network name).
+	Label string
+	// DiscordMentions are pinged by critical Discord alerts.
+	DiscordMentions discordMentions
+	// DiscordRoundThreads posts each round's alerts in a "Round N" thread of a Discord forum channel,
+	// or of a text channel when DiscordBotToken is set.
+	DiscordRoundThreads bool
+	// DiscordBotToken lets the bot find and create round threads that a webhook can't.
+	DiscordBotToken string
+	// Threaded posts follow-up alerts of a round as replies to the round's first message.
+	Threaded bool
+	threads  *alertThreads
+}
+
+// alert is a message sent to the alert channels.
+type alert struct {
+	Message string
+	Color   int
+	// Round is the round the alert belongs to, used to thread follow-ups (0 = not round related).
+	Round uint64
+	// Incident is the key of the incident the alert opens, if any, so it can be closed later.
+	Incident string
+	// Severity is info, warning or critical (default: the severity of the alert type).
+	Severity string
+	// Channels limits delivery to these channels, e.g. for escalation stages (nil = all channels).
+	Channels []string
+	// Elapsed is the time since the current round started, and RoundStart its start time.
+	Elapsed    time.Duration
+	RoundStart time.Time
+
+	// Event details for structured channels such as the generic webhook.
+	Type         string
+	Orchestrator common.Address
+	Block        uint64
+	Tx           string
+}
+
+// to reports whether the alert is delivered to the channel.
+func (a alert) to(channel string) bool {
+	if a.Channels == nil {
+		return true
+	}
+	for _, c := range a.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// configured reports whether at least one alert channel is set up.
+func (n *notifier) configured() bool {
+	if n.PagerDuty.RoutingKey != "" {
+		return true
+	}
+	for _, ch := range alertChannels {
+		if n.enabled(ch.id) {
+			return true
+		}
+	}
+	for _, sink := range eventSinks {
+		if n.sinkEnabled(sink.id) {
+			return true
+		}
+	}
+	return false
+}
+
+// send sends a message of the given event type that is not related to a specific round.
+func (n *notifier) send(kind, message string, color int) error {
+	return n.sendAlert(alert{Type: kind, Message: message, Color: color})
+}
+
+// alertChannels lists the supported alert channels in delivery order, mapped to their display names.
+var alertChannels = []struct{ id, name string }{
+	{"discord", "Discord"},
+	{"slack", "Slack"},
+	{"teams", "Teams"},
+	{"googlechat", "Google Chat"},
+	{"telegram", "Telegram"},
+	{"mattermost", "Mattermost"},
+	{"matrix", "Matrix"},
+	{"rocketchat", "Rocket.Chat"},
+	{"zulip", "Zulip"},
+	{"signal", "Signal"},
+	{"xmpp", "XMPP"},
+	{"irc", "IRC"},
+	{"opsgenie", "Opsgenie"},
+	{"pushover", "Pushover"},
+	{"ntfy", "ntfy"},
+	{"gotify", "Gotify"},
+	{"sms", "SMS"},
+	{"webhook", "Webhook"},
+	{"exec", "Command"},
+	{"urls", "Notify URLs"},
+	{"email", "Email"},
+}
+
+// validateChannels checks that all names refer to supported alert channels.
+func validateChannels(names []string) error {
+	for _, name := range names {
+		known := false
+		for _, ch := range alertChannels {
+			known = known || ch.id == name
+		}
+		if !known {
+			return fmt.Errorf("unknown alert channel %q", name)
+		}
+	}
+	return nil
+}
+
+// enabled reports whether the alert channel is configured.
+func (n *notifier) enabled(channel string) bool {
+	switch channel {
+	case "discord":
+		return n.DiscordWebhook != ""
+	case "slack":
+		return n.SlackWebhook != ""
+	case "teams":
+		return n.TeamsWebhook != ""
+	case "googlechat":
+		return n.GoogleChatWebhook != ""
+	case "telegram":
+		return n.TelegramBotToken != "" && n.TelegramChatID != ""
+	case "mattermost":
+		return n.Mattermost.WebhookURL != ""
+	case "matrix":
+		return n.Matrix.complete()
+	case "rocketchat":
+		return n.RocketChatWebhook != ""
+	case "zulip":
+		return n.Zulip.complete()
+	case "signal":
+		return n.Signal.complete()
+	case "xmpp":
+		return n.XMPP.complete()
+	case "irc":
+		return n.IRC.complete()
+	case "opsgenie":
+		return n.Opsgenie.APIKey != ""
+	case "pushover":
+		return n.Pushover.complete()
+	case "ntfy":
+		return n.Ntfy.Topic != ""
+	case "gotify":
+		return n.Gotify.complete()
+	case "sms":
+		return n.Twilio.complete()
+	case "webhook":
+		return n.Webhook.URL != ""
+	case "exec":
+		return n.Command != ""
+	case "urls":
+		return len(n.URLs) > 0
+	case "email":
+		return n.Email.complete()
+	}
+	return false
+}
+
+// accepts reports whether the channel wants the alert, i.e. the alert is at least as severe
+// as the channel's minimum severity.
+func (n *notifier) accepts(channel string, a alert) bool {
+	if channel == "email" && len(n.Email.recipients(a.severity())) == 0 {
+		// Only severities with their own recipients are emailed.
+		return false
+	}
+	return severityAtLeast(a.severity(), n.minSeverity(channel))
+}
+
+// isFallback reports whether the channel only receives alerts when all primary channels fail.
+func (n *notifier) isFallback(channel string) bool {
+	for _, f := range n.Fallback {
+		if f == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// sendAlert sends alerts to messaging platforms based on configuration. Fallback channels are
+// only used when no primary channel accepted the alert.
+func (n *notifier) sendAlert(a alert) error {
+	a.Message = n.Templates.render(a, n.Label)
+	// Errors in alert bodies can echo RPC URLs or tokens; never forward them.
+	a.Message = redact(a.Message)
+	a.Severity = a.severity()
+	message := a.Message
+	if n.Label != "" {
+		message = fmt.Sprintf("(%s) %s", n.Label, message)
+	}
+	defer n.publish(newAlertEvent(a, n.Label))
+	if !mutedUntil().IsZero() {
+		metrics.inc("reward_watcher_alerts_suppressed_total", "reason", "muted")
+		return nil
+	}
+	now := time.Now()
+	send, repeats := n.limiter.dedupe(a.Type+"\x00"+a.Message, n.DedupWindow, now)
+	if !send {
+		metrics.inc("reward_watcher_alerts_suppressed_total", "reason", "duplicate")
+		return nil
+	}
+	if repeats > 0 {
+		message += repeatNote(repeats, n.DedupWindow)
+	}
+	if !n.limiter.allow("", n.RateLimit, now) {
+		log.Printf("Alert rate limit reached, dropping %s alert", a.Type)
+		metrics.inc("reward_watcher_alerts_suppressed_total", "reason", "rate_limit")
+		return nil
+	}
+	if n.Timestamps {
+		message += alertTimes(a, now)
+	}
+	quiet := n.Quiet.remaining(now.In(location))
+	if quiet > 0 && a.Severity != severityCritical {
+		if n.Quiet.Queue {
+			n.hold(a, message, quiet)
+		}
+		return nil
+	}
+	return n.dispatch(a, message, quiet > 0)
+}
+
+// dispatch delivers a labelled alert to the channels that accept it. During quiet hours only
+// the quiet hours channels are used.
+func (n *notifier) dispatch(a alert, message string, quiet bool) error {
+	var failed []string
+	// The fallback channels only step in when a primary channel was supposed to get the alert
+	// and every such delivery failed, not when routing or quiet hours skipped all of them.
+	attempted, delivered := false, false
+	for _, ch := range alertChannels {
+		if !n.enabled(ch.id) || !n.accepts(ch.id, a) || !n.routed(ch.id, a.Type) {
+			continue
+		}
+		if quiet && !n.Quiet.allows(ch.id) || !a.to(ch.id) {
+			continue
+		}
+		if n.isFallback(ch.id) {
+			continue
+		}
+		attempted = true
+		if !n.limiter.allow(ch.id, n.ChannelRateLimits[ch.id], time.Now()) {
+			// A rate limited channel did not fail, so it doesn't trigger the fallback channels.
+			metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "rate_limited")
+			delivered = true
+			continue
+		}
+		if err := n.deliver(ch.id, a, message); err != nil {
+			log.Printf("%s alert error: %v", ch.name, err)
+			metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "error")
+			failed = append(failed, ch.name)
+		} else {
+			metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "ok")
+			delivered = true
+		}
+	}
+	if attempted && !delivered {
+		for _, ch := range alertChannels {
+			if !n.enabled(ch.id) || !n.accepts(ch.id, a) || !n.routed(ch.id, a.Type) || !n.isFallback(ch.id) {
+				continue
+			}
+			if quiet && !n.Quiet.allows(ch.id) || !a.to(ch.id) {
+				continue
+			}
+			if !n.limiter.allow(ch.id, n.ChannelRateLimits[ch.id], time.Now()) {
+				metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "rate_limited")
+				continue
+			}
+			if err := n.deliver(ch.id, a, message); err != nil {
+				log.Printf("%s fallback alert error: %v", ch.name, err)
+				metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "error")
+				failed = append(failed, ch.name)
+			} else {
+				metrics.inc("reward_watcher_alerts_total", "channel", ch.id, "result", "ok")
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("alert failed for: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// deliver sends an alert to a single channel. message is the labelled alert text.
+func (n *notifier) deliver(channel string, a alert, message string) error {
+	switch channel {
+	case "discord":
+		return deliverTargets(parseAlertTargets(n.DiscordWebhook), a.Severity, func(webhook string) error {
+			return n.deliverDiscord(webhook, a, message)
+		})
+	case "slack":
+		return sendSlackAlert(n.SlackWebhook, message, a.Color, n.Branding)
+	case "teams":
+		return sendTeamsAlert(n.TeamsWebhook, message, a.Severity, n.Branding)
+	case "googlechat":
+		return sendGoogleChatAlert(n.GoogleChatWebhook, message, a.Color, a.Severity, n.Branding)
+	case "telegram":
+		return deliverTargets(parseAlertTargets(n.TelegramChatID), a.Severity, func(chat string) error {
+			return n.deliverTelegram(chat, a, message)
+		})
+	case "mattermost":
+		return sendMattermostAlert(n.Mattermost, message, a.Color, a.Severity, n.Branding)
+	case "matrix":
+		return sendMatrixAlert(n.Matrix, message)
+	case "rocketchat":
+		return sendRocketChatAlert(n.RocketChatWebhook, message, a.Color, n.Branding)
+	case "zulip":
+		return sendZulipAlert(n.Zulip, message, a.Round)
+	case "signal":
+		return sendSignalAlert(n.Signal, message)
+	case "xmpp":
+		return sendXMPPAlert(n.XMPP, message)
+	case "irc":
+		return sendIRCAlert(n.IRC, message)
+	case "opsgenie":
+		return sendOpsgenieAlert(n.Opsgenie, message, a.Severity, a.Incident)
+	case "pushover":
+		title := n.Branding.Title
+		if title == "" {
+			title = defaultEmailSubject
+		}
+		return sendPushoverAlert(n.Pushover, title, message, a.Incident)
+	case "ntfy":
+		title := n.Branding.Title
+		if title == "" {
+			title = defaultEmailSubject
+		}
+		return sendNtfyAlert(n.Ntfy, title, message, a.Severity)
+	case "gotify":
+		title := n.Branding.Title
+		if title == "" {
+			title = defaultEmailSubject
+		}
+		return sendGotifyAlert(n.Gotify, title, message, a.Severity)
+	case "sms":
+		return sendTwilioSMS(n.Twilio, message)
+	case "webhook":
+		return sendWebhookAlert(n.Webhook, newAlertEvent(a, n.Label))
+	case "exec":
+		return runAlertCommand(n.Command, newAlertEvent(a, n.Label))
+	case "urls":
+		return n.deliverURLs(a, message)
+	case "email":
+		// Informational alerts wait for the digest when one is configured.
+		if interval, _ := n.Email.digestInterval(); interval > 0 && a.Severity == severityInfo && n.emailDigest != nil {
+			n.addToDigest(message, interval)
+			return nil
+		}
+		return n.sendEmail(n.emailSubject(), message, a.Color, a.Severity)
+	}
+	return nil
+}
+
+// emailSubject returns the subject of alert emails.
+func (n *notifier) emailSubject() string {
+	if n.Branding.Title != "" {
+		return n.Branding.Title
+	}
+	return defaultEmailSubject
+}
+
+// sendEmail renders a markdown message into the email template and sends it.
+func (n *notifier) sendEmail(subject, message string, color int, severity string) error {
+	text, html, err := n.renderEmail(subject, message, color, severity)
+	if err != nil {
+		return err
+	}
+	return sendEmailAlert(n.Email, n.Email.recipients(severity), subject, text, html)
+}
+
+var markdownLinkRe = regexp.MustCompile(`\[(.*?)\]\((.*?)\)`)
+
+// markdownBodyToHTML converts a markdown-formatted message to an HTML fragment.
+func markdownBodyToHTML(message string) string {
+	body := html.EscapeString(message)
+	body = markdownLinkRe.ReplaceAllStringFunc(body, func(match string) string {
+		parts := markdownLinkRe.FindStringSubmatch(match)
+		if len(parts) != 3 {
+			return match
+		}
+		return fmt.Sprintf(`<a href="%s">%s</a>`, parts[2], parts[1])
+	})
+	return strings.ReplaceAll(body, "\n", "<br>")
+}
+
+// deliverDiscord posts an alert through a single Discord webhook, in the round's thread if enabled.
+func (n *notifier) deliverDiscord(webhook string, a alert, message string) error {
+	var thread discordThread
+	if n.DiscordRoundThreads {
+		thread = n.threads.discordThread(webhook, a.Round)
+		if thread.ID == "" && n.DiscordBotToken != "" {
+			id, err := discordWebhookThread(n.DiscordBotToken, webhook, thread.Name)
+			if err != nil {
+				log.Printf("failed to look up Discord thread %q: %v", thread.Name, err)
+			} else if id != "" {
+				thread.ID = id
+				if a.Round != 0 {
+					n.threads.setDiscordThread(discordWebhookID(webhook), a.Round, id)
+				}
+			}
+		}
+	}
+	var mentions discordMentions
+	if a.Severity == severityCritical {
+		mentions = n.DiscordMentions
+	}
+	channelID, err := sendDiscordAlert(webhook, message, a.Color, thread, mentions, n.Branding)
+	if err != nil {
+		return err
+	}
+	if n.DiscordRoundThreads && thread.ID == "" && a.Round != 0 {
+		n.threads.setDiscordThread(discordWebhookID(webhook), a.Round, channelID)
+	}
+	return nil
+}
+
+// deliverTelegram sends an alert to a single Telegram chat, as a reply to the round's first
+// message if threading is enabled.
+func (n *notifier) deliverTelegram(chat string, a alert, message string) error {
+	threads := n.threads
+	if !n.Threaded || a.Round == 0 {
+		threads = nil
+	}
+	topic := n.telegramTopic(a.Type)
+	// Replies must stay in the topic of the message they answer.
+	root := fmt.Sprintf("%s/%d", chat, topic)
+	replyTo := threads.telegramRoot(root, a.Round)
+	// Only warnings and critical alerts make a sound.
+	silent := a.Severity == severityInfo
+	messageID, err := sendTelegramAlert(n.TelegramBotToken, chat, topic, message, replyTo, silent)
+	if err != nil {
+		return err
+	}
+	if replyTo == 0 {
+		threads.setTelegramRoot(root, a.Round, messageID)
+	}
+	return nil
+}
+
+// telegramEscaper escapes the characters reserved by Telegram's MarkdownV2.
+var telegramEscaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`,
+	"`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`,
+	"}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// telegramMarkupRe matches the markdown used in alerts: links and bold text.
+var telegramMarkupRe = regexp.MustCompile(`\[(.*?)\]\((.*?)\)|\*\*(.+?)\*\*`)
+
+// telegramMarkdownV2 converts the links and bold text of an alert to MarkdownV2 and escapes
+// everything else, so special characters are shown as is instead of breaking the message.
+func telegramMarkdownV2(message string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range telegramMarkupRe.FindAllStringSubmatchIndex(message, -1) {
+		b.WriteString(telegramEscaper.Replace(message[last:m[0]]))
+		if m[2] >= 0 {
+			url := strings.NewReplacer(`\`, `\\`, ")", `\)`).Replace(message[m[4]:m[5]])
+			fmt.Fprintf(&b, "[%s](%s)", telegramEscaper.Replace(message[m[2]:m[3]]), url)
+		} else {
+			fmt.Fprintf(&b, "*%s*", telegramEscaper.Replace(message[m[6]:m[7]]))
+		}
+		last = m[1]
+	}
+	b.WriteString(telegramEscaper.Replace(message[last:]))
+	return b.String()
+}
+
+// sendTelegramAlert sends a message to a Telegram chat using a bot, optionally in a forum topic,
+// as a reply to an earlier message or without a notification sound, and returns the ID of the
+// sent message.
+func sendTelegramAlert(botToken, chatID string, topic int, message string, replyTo int, silent bool) (int, error) {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+	payload := map[string]interface{}{"chat_id": chatID, "text": telegramMarkdownV2(message), "parse_mode": "MarkdownV2"}
+	if topic != 0 {
+		payload["message_thread_id"] = topic
+	}
+	if silent {
+		payload["disable_notification"] = true
+	}
+	if replyTo != 0 {
+		payload["reply_to_message_id"] = replyTo
+		payload["allow_sending_without_reply"] = true
+	}
+	body, _ := json.Marshal(payload)
+	resp, err := http.Post(url, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      struct {
+			MessageID int `json:"message_id"`
+		} `json:"result"`
+	}
+	// Telegram explains rejected messages, e.g. a parse error, in the response body.
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || !result.OK {
+		if result.Description != "" {
+			return 0, fmt.Errorf("telegram API returned HTTP %d: %s", resp.StatusCode, result.Description)
+		}
+		return 0, fmt.Errorf("telegram API returned HTTP %d", resp.StatusCode)
+	}
+	return result.Result.MessageID, nil
+}
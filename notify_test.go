@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestTelegramMarkdownV2(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"plain text", "Reward called", "Reward called"},
+		{"reserved characters", "Fee: 1.5% (cut) - done!", `Fee: 1\.5% \(cut\) \- done\!`},
+		{"backslash", `a\b`, `a\\b`},
+		{"bold", "**Round 42** started", `*Round 42* started`},
+		{"bold with reserved characters", "**v1.0_beta**", `*v1\.0\_beta*`},
+		{
+			"link",
+			"See [tx 0xab_cd](https://arbiscan.io/tx/0xabcd).",
+			`See [tx 0xab\_cd](https://arbiscan.io/tx/0xabcd)\.`,
+		},
+		{"link URL is not escaped", "[docs](https://example.com/a_b-c.md#x)", `[docs](https://example.com/a_b-c.md#x)`},
+		{"unmatched markup", "a ** b [c]", `a \*\* b \[c\]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := telegramMarkdownV2(tt.message); got != tt.want {
+				t.Errorf("telegramMarkdownV2(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
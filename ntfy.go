@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultNtfyServer = "https://ntfy.sh"
+
+// ntfyConfig configures the ntfy alert channel.
+type ntfyConfig struct {
+	// Server is the ntfy server URL (default: https://ntfy.sh).
+	Server string `json:"server"`
+	Topic  string `json:"topic"`
+	// Token is an access token for protected topics.
+	Token string `json:"token"`
+}
+
+// ntfyPriorities maps alert severities to ntfy priorities (1-5) and their tag, shown as an emoji.
+var ntfyPriorities = map[string]struct {
+	priority int
+	tag      string
+}{
+	"critical": {5, "rotating_light"},
+	"warning":  {4, "warning"},
+	"info":     {3, "bell"},
+}
+
+// sendNtfyAlert publishes an alert to a ntfy topic. Tapping the notification opens the first link
+// of the message, usually the orchestrator on the explorer.
+func sendNtfyAlert(cfg ntfyConfig, title, message, severity string) error {
+	server := cfg.Server
+	if server == "" {
+		server = defaultNtfyServer
+	}
+	level := ntfyPriorities[severity]
+	payload := map[string]interface{}{
+		"topic":    cfg.Topic,
+		"title":    title,
+		"message":  message,
+		"markdown": true,
+		"priority": level.priority,
+		"tags":     []string{level.tag},
+	}
+	if link := markdownLinkRe.FindStringSubmatch(message); link != nil {
+		payload["click"] = link[2]
+	}
+	var headers map[string]string
+	if cfg.Token != "" {
+		headers = map[string]string{"Authorization": "Bearer " + cfg.Token}
+	}
+	if err := postJSONWithHeaders(strings.TrimRight(server, "/"), headers, payload); err != nil {
+		return fmt.Errorf("ntfy server: %v", err)
+	}
+	return nil
+}
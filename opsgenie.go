@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const defaultOpsgenieAPIURL = "https://api.opsgenie.com"
+
+// opsgenieConfig configures the Opsgenie alert channel.
+type opsgenieConfig struct {
+	APIKey string `json:"apiKey"`
+	// APIURL selects the Opsgenie instance, e.g. https://api.eu.opsgenie.com for EU accounts.
+	APIURL string `json:"apiUrl"`
+	// Priorities overrides the Opsgenie priority (P1-P5) per alert severity: critical, warning or info.
+	Priorities map[string]string `json:"priorities"`
+}
+
+// defaultOpsgeniePriorities maps alert severities to Opsgenie priorities.
+var defaultOpsgeniePriorities = map[string]string{
+	"critical": "P1",
+	"warning":  "P3",
+	"info":     "P5",
+}
+
+func (c opsgenieConfig) endpoint(path string) string {
+	base := c.APIURL
+	if base == "" {
+		base = defaultOpsgenieAPIURL
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
+func (c opsgenieConfig) priority(severity string) string {
+	if p := c.Priorities[severity]; p != "" {
+		return p
+	}
+	return defaultOpsgeniePriorities[severity]
+}
+
+// sendOpsgenieAlert creates an Opsgenie alert. alias deduplicates alerts and lets the alert be
+// closed later; an empty alias lets Opsgenie generate one.
+func sendOpsgenieAlert(cfg opsgenieConfig, message, severity, alias string) error {
+	text := markdownLinkRe.ReplaceAllString(message, "$1 ($2)")
+	summary := strings.SplitN(markdownLinkRe.ReplaceAllString(message, "$1"), "\n", 2)[0]
+	// Opsgenie rejects alert messages longer than 130 characters.
+	if r := []rune(summary); len(r) > 130 {
+		summary = string(r[:129]) + "…"
+	}
+	payload := map[string]interface{}{
+		"message":     summary,
+		"description": text,
+		"priority":    cfg.priority(severity),
+		"source":      "livepeer-reward-watcher",
+	}
+	if alias != "" {
+		payload["alias"] = alias
+	}
+	headers := map[string]string{"Authorization": "GenieKey " + cfg.APIKey}
+	if err := postJSONWithHeaders(cfg.endpoint("/v2/alerts"), headers, payload); err != nil {
+		return fmt.Errorf("opsgenie API: %v", err)
+	}
+	return nil
+}
+
+// closeOpsgenieAlert closes the Opsgenie alert with the given alias.
+func closeOpsgenieAlert(cfg opsgenieConfig, alias string) error {
+	endpoint := cfg.endpoint("/v2/alerts/" + url.PathEscape(alias) + "/close?identifierType=alias")
+	headers := map[string]string{"Authorization": "GenieKey " + cfg.APIKey}
+	payload := map[string]string{"source": "livepeer-reward-watcher", "note": "Reward called."}
+	if err := postJSONWithHeaders(endpoint, headers, payload); err != nil {
+		return fmt.Errorf("opsgenie API: %v", err)
+	}
+	return nil
+}
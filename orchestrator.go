@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// orchestratorConfig is an orchestrator watched on a network.
+type orchestratorConfig struct {
+	Address common.Address
+	// RewardCaller is the account that submits reward transactions, which defaults to the orchestrator.
+	RewardCaller common.Address
+}
+
+// orchestrator holds the state a watcher tracks independently for every orchestrator.
+type orchestrator struct {
+	address      common.Address
+	rewardCaller common.Address
+
+	// Round state.
+	rewardCalled bool
+	sentWarning  bool
+	warnings     int
+	// firstWarning and escalationLevel track the escalation of the missed-reward alert.
+	firstWarning    time.Time
+	escalationLevel int
+	// warningStage is the last --warning-stages stage warned about.
+	warningStage  int
+	finalCallSent bool
+	autoReward    autoRewardState
+	pendingReward pendingRewardState
+	handledReward *handledReward
+	roundFees     *big.Int
+	roundTickets  int
+	roundTreasury *big.Int
+	treasuryTotal *big.Int
+	// rewardTime, roundMinted and roundStartStake feed the end-of-round summary.
+	rewardTime      time.Time
+	roundMinted     *big.Int
+	roundStartStake *big.Int
+	// roundRewardFee is the ETH paid for the round's reward transactions.
+	roundRewardFee *big.Int
+
+	// lastRewardRound, lastRewardTime and lastRewardTx describe the last reward call, across rounds.
+	lastRewardRound uint64
+	lastRewardTime  time.Time
+	lastRewardTx    string
+
+	deactivationRound        uint64
+	deactivationAlertedRound uint64
+	rewardGas                gasTracker
+	nonceGap                 nonceGapState
+	lowBalance               bool
+	serviceURI               serviceURIState
+}
+
+func newOrchestrator(cfg orchestratorConfig) *orchestrator {
+	return &orchestrator{
+		address:        cfg.Address,
+		rewardCaller:   cfg.RewardCaller,
+		roundFees:      new(big.Int),
+		roundTreasury:  new(big.Int),
+		roundMinted:    new(big.Int),
+		roundRewardFee: new(big.Int),
+		treasuryTotal:  new(big.Int),
+	}
+}
+
+// link returns a markdown link to the orchestrator on the Livepeer explorer, used to label alerts.
+func (o *orchestrator) link() string {
+	address := strings.ToLower(o.address.Hex())
+	return fmt.Sprintf("[%s](https://explorer.livepeer.org/accounts/%s/delegating)", address, address)
+}
+
+// resetRound clears the round state when a new round starts.
+func (o *orchestrator) resetRound() {
+	o.roundFees = new(big.Int)
+	o.roundTickets = 0
+	o.roundTreasury = new(big.Int)
+	o.roundMinted = new(big.Int)
+	o.roundRewardFee = new(big.Int)
+	o.rewardTime = time.Time{}
+	o.rewardCalled = false
+	o.sentWarning = false
+	o.warnings = 0
+	o.firstWarning = time.Time{}
+	o.escalationLevel = 0
+	o.warningStage = 0
+	o.finalCallSent = false
+	o.autoReward = autoRewardState{}
+	o.pendingReward = pendingRewardState{}
+}
+
+// orchestratorByTopic returns the watched orchestrator whose address is in an indexed event topic.
+func (w *watcher) orchestratorByTopic(topic common.Hash) *orchestrator {
+	address := common.BytesToAddress(topic.Bytes())
+	for _, o := range w.orchestrators {
+		if o.address == address {
+			return o
+		}
+	}
+	return nil
+}
+
+// orchestratorTopics returns the orchestrator addresses as event topics for subscription filters.
+func (w *watcher) orchestratorTopics() []common.Hash {
+	topics := make([]common.Hash, 0, len(w.orchestrators))
+	for _, o := range w.orchestrators {
+		topics = append(topics, common.BytesToHash(o.address.Bytes()))
+	}
+	return topics
+}
+
+// parseOrchestrators pairs orchestrator addresses with their reward callers. A single reward
+// caller applies to all orchestrators; an empty list means each orchestrator calls reward itself.
+func parseOrchestrators(addresses, rewardCallers []string) ([]orchestratorConfig, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no orchestrator address given")
+	}
+	if len(rewardCallers) > 1 && len(rewardCallers) != len(addresses) {
+		return nil, fmt.Errorf("got %d reward callers for %d orchestrators", len(rewardCallers), len(addresses))
+	}
+	out := make([]orchestratorConfig, 0, len(addresses))
+	for i, a := range addresses {
+		if !common.IsHexAddress(a) {
+			return nil, fmt.Errorf("invalid orchestrator address %q", a)
+		}
+		cfg := orchestratorConfig{Address: common.HexToAddress(a), RewardCaller: common.HexToAddress(a)}
+		caller := ""
+		if len(rewardCallers) == 1 {
+			caller = rewardCallers[0]
+		} else if len(rewardCallers) > 1 {
+			caller = rewardCallers[i]
+		}
+		if caller != "" {
+			if !common.IsHexAddress(caller) {
+				return nil, fmt.Errorf("invalid reward caller address %q", caller)
+			}
+			cfg.RewardCaller = common.HexToAddress(caller)
+		}
+		out = append(out, cfg)
+	}
+	return out, nil
+}
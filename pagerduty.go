@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutyConfig configures paging through the PagerDuty Events API v2.
+type pagerDutyConfig struct {
+	RoutingKey string `json:"routingKey"`
+	// Severity of triggered incidents: critical, error, warning or info (default: critical).
+	Severity string `json:"severity"`
+}
+
+// incident is a problem that stays open until it is resolved, e.g. a missed reward call.
+type incident struct {
+	// Key identifies the incident so that repeated triggers and the resolve refer to the same one.
+	Key     string
+	Summary string
+	Source  string
+}
+
+// rewardIncident returns the incident raised when the orchestrator misses reward in a round.
+func rewardIncident(o *orchestrator, round uint64, summary string) incident {
+	return incident{
+		Key:     fmt.Sprintf("livepeer-reward-%s-%d", strings.ToLower(o.address.Hex()), round),
+		Summary: summary,
+		Source:  strings.ToLower(o.address.Hex()),
+	}
+}
+
+// sendPagerDutyEvent sends a trigger or resolve event for an incident.
+func sendPagerDutyEvent(cfg pagerDutyConfig, action string, inc incident) error {
+	event := map[string]interface{}{
+		"routing_key":  cfg.RoutingKey,
+		"event_action": action,
+		"dedup_key":    inc.Key,
+	}
+	if action == "trigger" {
+		severity := cfg.Severity
+		if severity == "" {
+			severity = "critical"
+		}
+		event["payload"] = map[string]string{
+			"summary":  markdownLinkRe.ReplaceAllString(inc.Summary, "$1"),
+			"source":   inc.Source,
+			"severity": severity,
+		}
+	}
+	if err := postJSON(pagerDutyEventsURL, event); err != nil {
+		return fmt.Errorf("pagerduty %s failed: %v", action, err)
+	}
+	return nil
+}
+
+// triggerIncident opens an incident on PagerDuty. Triggering an open incident again
+// is deduplicated by its key.
+func (n *notifier) triggerIncident(inc incident) error {
+	if n.PagerDuty.RoutingKey == "" {
+		return nil
+	}
+	inc.Summary = redact(inc.Summary)
+	if n.Label != "" {
+		inc.Summary = fmt.Sprintf("(%s) %s", n.Label, inc.Summary)
+	}
+	err := sendPagerDutyEvent(n.PagerDuty, "trigger", inc)
+	n.recordIncidentResult(err)
+	return err
+}
+
+// resolveIncident closes an incident on PagerDuty, and closes the Opsgenie alert and stops the
+// Pushover emergency retries of the alert that opened it.
+func (n *notifier) resolveIncident(inc incident) {
+	if n.PagerDuty.RoutingKey != "" {
+		n.recordIncidentResult(sendPagerDutyEvent(n.PagerDuty, "resolve", inc))
+	}
+	if n.enabled("opsgenie") {
+		err := closeOpsgenieAlert(n.Opsgenie, inc.Key)
+		if err != nil {
+			log.Printf("Opsgenie alert error: %v", err)
+		}
+		recordChannelResult("opsgenie", err)
+	}
+	if n.enabled("pushover") {
+		if err := cancelPushoverEmergency(n.Pushover, inc.Key); err != nil {
+			log.Printf("Pushover alert error: %v", err)
+		}
+	}
+}
+
+func (n *notifier) recordIncidentResult(err error) {
+	if err != nil {
+		log.Printf("PagerDuty alert error: %v", err)
+	}
+	recordChannelResult("pagerduty", err)
+}
+
+// recordChannelResult counts a delivery to an alert channel in the alert metrics.
+func recordChannelResult(channel string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	metrics.inc("reward_watcher_alerts_total", "channel", channel, "result", result)
+}
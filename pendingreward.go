@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// pendingRewardState tracks a reward transaction of the reward caller waiting in the mempool.
+type pendingRewardState struct {
+	hash         common.Hash
+	since        time.Time
+	notified     bool
+	stuckAlerted bool
+}
+
+// pendingRewardTx returns the reward transaction of the orchestrator's reward caller waiting in
+// the RPC's mempool, read with txpool_contentFrom. RPCs without the txpool API return nil.
+func (w *watcher) pendingRewardTx(o *orchestrator) *blockTx {
+	if w.txpoolUnsupported {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var content map[string]map[string]blockTx
+	if err := w.client.Client().CallContext(ctx, &content, "txpool_contentFrom", o.rewardCaller); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 || strings.Contains(strings.ToLower(err.Error()), "unsupported method") {
+			w.log.Printf("RPC does not support txpool_contentFrom, pending reward transactions are not detected")
+			w.txpoolUnsupported = true
+		} else {
+			w.log.Printf("failed to read pending transactions of reward caller %s: %v", o.rewardCaller.Hex(), err)
+		}
+		return nil
+	}
+	for _, tx := range content["pending"] {
+		if tx.To == nil || *tx.To != w.net.Contracts.BondingManager || len(tx.Input) < 4 {
+			continue
+		}
+		if m, err := w.abis.BondingManager.MethodById(tx.Input[:4]); err == nil && strings.HasPrefix(m.Name, "reward") {
+			return &tx
+		}
+	}
+	return nil
+}
+
+// reportPendingReward replaces the missed-reward warning on every check while the reward
+// transaction is still pending, saying how long it has been pending, and alerts once it has been
+// pending for longer than --stuck-tx-timeout.
+func (w *watcher) reportPendingReward(o *orchestrator, tx *blockTx) {
+	hash := tx.Hash.Hex()
+	if o.pendingReward.hash != tx.Hash {
+		o.pendingReward = pendingRewardState{hash: tx.Hash, since: time.Now()}
+	}
+	pending := time.Since(o.pendingReward.since)
+	if o.pendingReward.stuckAlerted || w.opts.stuckTxTimeout <= 0 || pending < w.opts.stuckTxTimeout {
+		if o.pendingReward.notified && !w.opts.repeat {
+			return
+		}
+		o.pendingReward.notified = true
+		msg := fmt.Sprintf(
+			tr("⏳ Reward for %s in round %d is not confirmed yet, but reward transaction [%s](https://arbiscan.io/tx/%s) has been pending in the mempool for %s."),
+			o.link(), w.currentRound, hash, hash, formatDuration(pending.Round(time.Minute)))
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "reward_tx_pending", msg, 0xFFA500)
+		return
+	}
+	o.pendingReward.stuckAlerted = true
+	msg := fmt.Sprintf(
+		tr("❌ Reward transaction [%s](https://arbiscan.io/tx/%s) for %s in round %d has been pending in the mempool for %s. It may be stuck, e.g. with a gas price below the current base fee."),
+		hash, hash, o.link(), w.currentRound, formatDuration(pending.Round(time.Minute)))
+	w.log.Println(msg)
+	w.orchestratorAlert(o, "reward_tx_stuck", msg, 0xFF0000)
+}
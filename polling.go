@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// subscriptionsUnsupported reports whether a subscription failed because the endpoint doesn't
+// support eth_subscribe, as with most HTTPS endpoints.
+func subscriptionsUnsupported(err error) bool {
+	var rpcErr rpc.Error
+	return errors.Is(err, rpc.ErrNotificationsUnsupported) || errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
+}
+
+// pollLogs emulates a log subscription by querying new blocks with eth_getLogs every
+// --poll-interval, starting after the current head.
+func (w *watcher) pollLogs(query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	client, interval, chunkSize := w.client, w.opts.pollInterval, w.opts.logChunkSize
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	next, err := client.BlockNumber(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	next++
+	return gethevent.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-quit:
+				return nil
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			head, err := client.BlockNumber(ctx)
+			cancel()
+			if err != nil {
+				return err
+			}
+			// Query the new blocks in chunks, as most RPC providers limit the range of a query.
+			for next <= head {
+				end := chunkEnd(next, head, chunkSize)
+				query.FromBlock, query.ToBlock = new(big.Int).SetUint64(next), new(big.Int).SetUint64(end)
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				logs, err := client.FilterLogs(ctx, query)
+				cancel()
+				if err != nil {
+					return err
+				}
+				for _, l := range logs {
+					select {
+					case ch <- l:
+					case <-quit:
+						return nil
+					}
+				}
+				next = end + 1
+			}
+		}
+	}), nil
+}
+
+// pollHeads emulates a new head subscription by reading the latest header every --poll-interval.
+func (w *watcher) pollHeads(ch chan<- *types.Header) ethereum.Subscription {
+	client, interval := w.client, w.opts.pollInterval
+	return gethevent.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var last uint64
+		for {
+			select {
+			case <-ticker.C:
+			case <-quit:
+				return nil
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			head, err := client.HeaderByNumber(ctx, nil)
+			cancel()
+			if err != nil {
+				return err
+			}
+			if head.Number.Uint64() <= last {
+				continue
+			}
+			last = head.Number.Uint64()
+			select {
+			case ch <- head:
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
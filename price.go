@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CoinGecko identifiers of the assets the watcher values.
+const (
+	coinGeckoLPT = "livepeer"
+	coinGeckoETH = "ethereum"
+)
+
+var priceHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// priceCacheTTL is how long fetched prices are reused, to stay within the CoinGecko rate limits.
+const priceCacheTTL = 5 * time.Minute
+
+// priceCache holds recently fetched prices by asset and quote currency.
+var priceCache = struct {
+	mu     sync.Mutex
+	prices map[string]cachedPrice
+}{prices: make(map[string]cachedPrice)}
+
+type cachedPrice struct {
+	price   float64
+	fetched time.Time
+}
+
+// cachedFetchPrice returns the price of an asset like fetchPrice, reusing prices younger than priceCacheTTL.
+func cachedFetchPrice(coinID, currency string) (float64, error) {
+	key := coinID + "/" + strings.ToLower(currency)
+	priceCache.mu.Lock()
+	cached, ok := priceCache.prices[key]
+	priceCache.mu.Unlock()
+	if ok && time.Since(cached.fetched) < priceCacheTTL {
+		return cached.price, nil
+	}
+	price, err := fetchPrice(coinID, currency)
+	if err != nil {
+		return 0, err
+	}
+	priceCache.mu.Lock()
+	priceCache.prices[key] = cachedPrice{price, time.Now()}
+	priceCache.mu.Unlock()
+	return price, nil
+}
+
+// fiatValue returns " (≈ 12.34 USD)" for an amount of an 18-decimal asset, or "" when valuation
+// is disabled or the price is unavailable.
+func (w *watcher) fiatValue(coinID string, amount *big.Int) string {
+	if w.opts.fiatCurrency == "" || amount == nil || w.silent {
+		return ""
+	}
+	price, err := cachedFetchPrice(coinID, w.opts.fiatCurrency)
+	if err != nil {
+		w.log.Printf("failed to fetch %s price: %v", coinID, err)
+		return ""
+	}
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(1e18)).Float64()
+	return fmt.Sprintf(" (≈ %.2f %s)", value*price, strings.ToUpper(w.opts.fiatCurrency))
+}
+
+// fetchPrice returns the current price of a CoinGecko asset in the given quote currency (e.g. "usd").
+func fetchPrice(coinID, currency string) (float64, error) {
+	currency = strings.ToLower(currency)
+	u := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s",
+		url.QueryEscape(coinID), url.QueryEscape(currency))
+	resp, err := priceHTTPClient.Get(u)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price request failed: HTTP %d", resp.StatusCode)
+	}
+	var prices map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return 0, fmt.Errorf("failed to parse price response: %v", err)
+	}
+	price, ok := prices[coinID][currency]
+	if !ok {
+		return 0, fmt.Errorf("no %s price for %s", currency, coinID)
+	}
+	return price, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// primaryMaxLag is how many blocks the primary RPC may trail before it is not switched back to.
+const primaryMaxLag = 40
+
+// primaryRPC returns the first configured RPC endpoint, which is preferred while it is healthy.
+func (w *watcher) primaryRPC() string {
+	if len(w.net.RPCs) == 0 {
+		return ""
+	}
+	return w.net.RPCs[0]
+}
+
+// primaryHealthy reports whether the last probe of the primary RPC succeeded and its chain head
+// was at most primaryMaxLag blocks behind head. A primary that was never probed counts as healthy.
+func (w *watcher) primaryHealthy(head uint64) bool {
+	h := w.rpcHealth[w.primaryRPC()]
+	if h == nil || h.probes == 0 {
+		return true
+	}
+	return h.lastError == "" && h.head+primaryMaxLag >= head
+}
+
+// checkPrimaryRPC probes the primary RPC while the watcher runs on a backup and reconnects to
+// the primary once it is healthy again.
+func (w *watcher) checkPrimaryRPC() {
+	primary := w.primaryRPC()
+	if primary == "" || w.rpcURL == "" || w.rpcURL == primary {
+		return
+	}
+	h := w.rpcHealth[primary]
+	if h == nil {
+		h = &rpcHealth{}
+		w.rpcHealth[primary] = h
+	}
+	probeRPC(primary, h)
+	if h.lastError != "" {
+		w.log.Printf("Primary RPC %s is still unavailable: %s", maskRPCURL(primary), h.lastError)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		// The keepalive probe handles a failing connection.
+		return
+	}
+	if !w.primaryHealthy(head) {
+		w.log.Printf("Primary RPC %s is %d blocks behind %s, staying on the backup", maskRPCURL(primary), head-h.head, maskRPCURL(w.rpcURL))
+		return
+	}
+	w.log.Printf("Primary RPC %s is healthy again, switching back from %s", maskRPCURL(primary), maskRPCURL(w.rpcURL))
+	w.returningToPrimary = true
+	w.reconnectRequested = true
+}
+
+// reportPrimaryReturn notes a switch back to the primary RPC after a failover.
+func (w *watcher) reportPrimaryReturn(from, to string) {
+	msg := fmt.Sprintf(tr("↩️ Switched back from the backup RPC %s to the primary RPC %s."), maskRPCURL(from), maskRPCURL(to))
+	w.log.Println(msg)
+	if w.opts.enableRPCAlerts {
+		w.alert("rpc_primary_restored", msg, 0x00FF00)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const pushoverAPIURL = "https://api.pushover.net/1"
+
+// Pushover priorities.
+const (
+	pushoverNormalPriority    = 0
+	pushoverEmergencyPriority = 2
+)
+
+// pushoverConfig configures the Pushover alert channel.
+type pushoverConfig struct {
+	AppToken string `json:"appToken"`
+	UserKey  string `json:"userKey"`
+	// Retry and Expire control how often (in seconds) an emergency alert is repeated until it is
+	// acknowledged, and for how long (default: every 60s for 1 hour).
+	Retry  int `json:"retry"`
+	Expire int `json:"expire"`
+}
+
+func (c pushoverConfig) complete() bool {
+	return c.AppToken != "" && c.UserKey != ""
+}
+
+// sendPushoverAlert sends a push notification. Alerts that open an incident are sent with
+// emergency priority and tagged with the incident key, so they can be cancelled once resolved.
+func sendPushoverAlert(cfg pushoverConfig, title, message string, incident string) error {
+	payload := map[string]interface{}{
+		"token":    cfg.AppToken,
+		"user":     cfg.UserKey,
+		"title":    title,
+		"message":  strings.ReplaceAll(markdownBodyToHTML(message), "<br>", "\n"),
+		"html":     1,
+		"priority": pushoverNormalPriority,
+	}
+	if incident != "" {
+		retry, expire := cfg.Retry, cfg.Expire
+		if retry == 0 {
+			retry = 60
+		}
+		if expire == 0 {
+			expire = 3600
+		}
+		payload["priority"] = pushoverEmergencyPriority
+		payload["retry"] = retry
+		payload["expire"] = expire
+		payload["tags"] = incident
+	}
+	if err := postJSON(pushoverAPIURL+"/messages.json", payload); err != nil {
+		return fmt.Errorf("pushover API: %v", err)
+	}
+	return nil
+}
+
+// cancelPushoverEmergency stops the retries of the emergency alerts of an incident.
+func cancelPushoverEmergency(cfg pushoverConfig, incident string) error {
+	payload := map[string]string{"token": cfg.AppToken}
+	if err := postJSON(pushoverAPIURL+"/receipts/cancel_by_tag/"+incident+".json", payload); err != nil {
+		return fmt.Errorf("pushover API: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quietHours is a daily do-not-disturb window in local time. During the window only critical
+// alerts are delivered; the others are dropped, or queued until the window ends.
+type quietHours struct {
+	// Window is the daily window as "HH:MM-HH:MM", e.g. "23:00-07:00". Empty disables quiet hours.
+	Window string `json:"window"`
+	// Queue delivers the suppressed alerts when the window ends instead of dropping them.
+	Queue bool `json:"queue"`
+	// Channels limits the critical alerts sent during the window to these channels (default: all).
+	Channels []string `json:"channels"`
+}
+
+// parseClock parses a "HH:MM" time of day into minutes after midnight.
+func parseClock(s string) (int, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(s), ":")
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if !ok || errH != nil || errM != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return h*60 + m, nil
+}
+
+// bounds returns the start and end of the window in minutes after midnight.
+func (q quietHours) bounds() (start, end int, err error) {
+	from, to, ok := strings.Cut(q.Window, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q, expected HH:MM-HH:MM", q.Window)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("quiet hours %q are empty", q.Window)
+	}
+	return start, end, nil
+}
+
+func (q quietHours) validate() error {
+	if q.Window == "" {
+		return nil
+	}
+	if _, _, err := q.bounds(); err != nil {
+		return err
+	}
+	return validateChannels(q.Channels)
+}
+
+// remaining returns how long the window still lasts at t, or 0 when t is outside the window.
+func (q quietHours) remaining(t time.Time) time.Duration {
+	if q.Window == "" {
+		return 0
+	}
+	start, end, err := q.bounds()
+	if err != nil {
+		return 0
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	now := int(t.Sub(midnight) / time.Minute)
+	var left int
+	switch {
+	case start < end && now >= start && now < end:
+		left = end - now
+	case start > end && now >= start:
+		left = 24*60 - now + end
+	case start > end && now < end:
+		left = end - now
+	default:
+		return 0
+	}
+	return time.Duration(left)*time.Minute - time.Duration(t.Second())*time.Second
+}
+
+// allows reports whether the channel receives critical alerts during the window.
+func (q quietHours) allows(channel string) bool {
+	if len(q.Channels) == 0 {
+		return true
+	}
+	for _, c := range q.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// quietQueue holds the alerts suppressed during quiet hours until the window ends.
+type quietQueue struct {
+	mu        sync.Mutex
+	alerts    []queuedAlert
+	scheduled bool
+}
+
+type queuedAlert struct {
+	a       alert
+	message string
+}
+
+func newQuietQueue() *quietQueue {
+	return &quietQueue{}
+}
+
+// hold queues an alert suppressed during quiet hours and schedules its delivery for when the
+// window ends.
+func (n *notifier) hold(a alert, message string, wait time.Duration) {
+	q := n.quietQueue
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.alerts = append(q.alerts, queuedAlert{a, message})
+	if !q.scheduled {
+		q.scheduled = true
+		time.AfterFunc(wait, n.flushQuietQueue)
+	}
+}
+
+// flushQuietQueue delivers the alerts queued during quiet hours.
+func (n *notifier) flushQuietQueue() {
+	q := n.quietQueue
+	q.mu.Lock()
+	queued := q.alerts
+	q.alerts, q.scheduled = nil, false
+	q.mu.Unlock()
+	if len(queued) == 0 {
+		return
+	}
+	log.Printf("Quiet hours ended, sending %d queued alert(s)", len(queued))
+	for _, qa := range queued {
+		if err := n.dispatch(qa.a, qa.message, false); err != nil {
+			log.Printf("queued alert error: %v", err)
+		}
+	}
+}
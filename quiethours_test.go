@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       quietHours
+		wantErr bool
+	}{
+		{name: "disabled", q: quietHours{}},
+		{name: "overnight", q: quietHours{Window: "23:00-07:00"}},
+		{name: "daytime with channels", q: quietHours{Window: "12:30-13:45", Channels: []string{"sms"}}},
+		{name: "missing separator", q: quietHours{Window: "23:00"}, wantErr: true},
+		{name: "hour out of range", q: quietHours{Window: "24:00-07:00"}, wantErr: true},
+		{name: "minute out of range", q: quietHours{Window: "23:60-07:00"}, wantErr: true},
+		{name: "not a time", q: quietHours{Window: "night-07:00"}, wantErr: true},
+		{name: "empty window", q: quietHours{Window: "07:00-07:00"}, wantErr: true},
+		{name: "unknown channel", q: quietHours{Window: "23:00-07:00", Channels: []string{"fax"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.q.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuietHoursRemaining(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		window string
+		t      time.Time
+		want   time.Duration
+	}{
+		{"disabled", "", at(23, 30), 0},
+		{"before overnight window", "23:00-07:00", at(22, 59), 0},
+		{"overnight before midnight", "23:00-07:00", at(23, 30), 7*time.Hour + 30*time.Minute},
+		{"overnight after midnight", "23:00-07:00", at(6, 0), time.Hour},
+		{"overnight window ended", "23:00-07:00", at(7, 0), 0},
+		{"daytime window", "12:00-13:00", at(12, 15), 45 * time.Minute},
+		{"after daytime window", "12:00-13:00", at(13, 15), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (quietHours{Window: tt.window}).remaining(tt.t); got != tt.want {
+				t.Errorf("remaining(%s) = %s, want %s", tt.t.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// quorumRecheckInterval throttles how often an event is looked up on the other RPCs.
+	quorumRecheckInterval = 10 * time.Second
+	// quorumTimeout is how long an event may wait for a quorum before it is dropped.
+	quorumTimeout = 5 * time.Minute
+)
+
+// quorumState tracks the cross-check of a queued event against the other RPC endpoints.
+type quorumState struct {
+	first, checked time.Time
+	disagreed      bool
+}
+
+// quorumClient returns a client for a secondary RPC endpoint, dialing it on first use.
+func (w *watcher) quorumClient(url string) (*ethclient.Client, error) {
+	if c, ok := w.quorumClients[url]; ok {
+		return c, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rc, err := rpc.DialOptions(ctx, url, rpcDialOptions(url)...)
+	if err != nil {
+		return nil, err
+	}
+	c := ethclient.NewClient(rc)
+	w.quorumClients[url] = c
+	return c, nil
+}
+
+// closeQuorumClients closes the connections to the secondary RPC endpoints.
+func (w *watcher) closeQuorumClients() {
+	for url, c := range w.quorumClients {
+		c.Close()
+		delete(w.quorumClients, url)
+	}
+}
+
+// quorumReached reports whether at least --quorum RPC endpoints, counting the connected one,
+// have the event in the receipt of its transaction. It alerts when an endpoint reports the
+// transaction with different logs or in a different block, and drops the event with an alert
+// when no quorum is reached within quorumTimeout.
+func (w *watcher) quorumReached(u *unconfirmedLog) (reached, drop bool) {
+	now := time.Now()
+	if u.quorum.first.IsZero() {
+		u.quorum.first = now
+	}
+	if now.Sub(u.quorum.checked) < quorumRecheckInterval {
+		return false, false
+	}
+	u.quorum.checked = now
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	seen := 1
+	var disagreeing []string
+	for _, url := range w.net.RPCs {
+		if url == w.rpcURL {
+			continue
+		}
+		c, err := w.quorumClient(url)
+		if err != nil {
+			continue
+		}
+		receipt, err := c.TransactionReceipt(ctx, u.log.TxHash)
+		if err != nil {
+			// Not found yet on a lagging endpoint, or unreachable.
+			continue
+		}
+		if receiptHasLog(receipt, u.log) {
+			seen++
+		} else {
+			disagreeing = append(disagreeing, maskRPCURL(url))
+		}
+	}
+	name := w.eventName(u.log)
+	txHash := u.log.TxHash.Hex()
+	if len(disagreeing) > 0 && !u.quorum.disagreed {
+		u.quorum.disagreed = true
+		msg := fmt.Sprintf(
+			tr("⚠️ RPC endpoints disagree about the %s event of [tx %s](https://arbiscan.io/tx/%s) in block %d: %s report it differently than %s."),
+			name, txHash, txHash, u.log.BlockNumber, strings.Join(disagreeing, ", "), maskRPCURL(w.rpcURL))
+		w.log.Println(msg)
+		w.alert("rpc_disagreement", msg, 0xFFA500)
+	}
+	if seen >= w.opts.quorum {
+		return true, false
+	}
+	if now.Sub(u.quorum.first) < quorumTimeout {
+		return false, false
+	}
+	msg := fmt.Sprintf(
+		tr("⚠️ Only %d of the %d required RPC endpoints confirmed the %s event of [tx %s](https://arbiscan.io/tx/%s) within %s, so it is ignored."),
+		seen, w.opts.quorum, name, txHash, txHash, formatDuration(quorumTimeout))
+	w.log.Println(msg)
+	w.alert("rpc_disagreement", msg, 0xFFA500)
+	return false, true
+}
+
+// receiptHasLog reports whether a receipt contains the log in the same block.
+func receiptHasLog(receipt *types.Receipt, vLog types.Log) bool {
+	if receipt.BlockHash != vLog.BlockHash {
+		return false
+	}
+	for _, l := range receipt.Logs {
+		if l.Index != vLog.Index || l.Address != vLog.Address || !bytes.Equal(l.Data, vLog.Data) || len(l.Topics) != len(vLog.Topics) {
+			continue
+		}
+		same := true
+		for i := range l.Topics {
+			same = same && l.Topics[i] == vLog.Topics[i]
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// eventName returns the name of a watched contract event, for alerts.
+func (w *watcher) eventName(vLog types.Log) string {
+	if len(vLog.Topics) == 0 {
+		return "unknown"
+	}
+	for _, contractABI := range []*abi.ABI{&w.abis.BondingManager, &w.abis.RoundsManager, &w.abis.TicketBroker} {
+		if event, err := contractABI.EventByID(vLog.Topics[0]); err == nil {
+			return event.Name
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const redacted = "[REDACTED]"
+
+// minSecretLen avoids redacting short values (e.g. a port or chat ID) that would mangle
+// unrelated text.
+const minSecretLen = 6
+
+// secretRegistry holds the secret values that must never appear in logs or alerts.
+type secretRegistry struct {
+	mu           sync.RWMutex
+	replacements map[string]string
+	replacer     *strings.Replacer
+}
+
+var secrets = &secretRegistry{replacements: make(map[string]string)}
+
+// Patterns that look like credentials even when they were never registered, e.g. a bot
+// token echoed back by an API error or credentials embedded in a URL.
+var (
+	telegramTokenRe = regexp.MustCompile(`bot[0-9]{5,}:[A-Za-z0-9_-]{20,}`)
+	urlUserinfoRe   = regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+@`)
+)
+
+// registerSecret marks a value as secret so it is scrubbed by redact. URLs are replaced by
+// their masked scheme://host form and their credentials, path tokens and query values are
+// registered as well, so they are also caught when printed on their own.
+func registerSecret(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) < minSecretLen {
+		return
+	}
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		secrets.replacements[value] = maskRPCURL(value)
+		if p, ok := u.User.Password(); ok && len(p) >= minSecretLen {
+			secrets.replacements[p] = redacted
+		}
+		for _, segment := range strings.Split(u.Path, "/") {
+			// Long path segments are API keys or webhook tokens, short ones are routes.
+			if len(segment) >= 20 {
+				secrets.replacements[segment] = redacted
+			}
+		}
+		for _, values := range u.Query() {
+			for _, v := range values {
+				if len(v) >= minSecretLen {
+					secrets.replacements[v] = redacted
+				}
+			}
+		}
+	} else {
+		secrets.replacements[value] = redacted
+	}
+
+	// Replace longer secrets first so a URL isn't partially redacted by its own token.
+	keys := make([]string, 0, len(secrets.replacements))
+	for k := range secrets.replacements {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	pairs := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k, secrets.replacements[k])
+	}
+	secrets.replacer = strings.NewReplacer(pairs...)
+}
+
+// redact scrubs registered secrets and credential-like patterns from s.
+func redact(s string) string {
+	secrets.mu.RLock()
+	replacer := secrets.replacer
+	secrets.mu.RUnlock()
+	if replacer != nil {
+		s = replacer.Replace(s)
+	}
+	s = telegramTokenRe.ReplaceAllString(s, "bot"+redacted)
+	return urlUserinfoRe.ReplaceAllString(s, "${1}"+redacted+"@")
+}
+
+// redactingWriter scrubs secrets from everything written through it.
+type redactingWriter struct {
+	w io.Writer
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// logOutput is the destination of all log output.
+var logOutput io.Writer = redactingWriter{os.Stderr}
+
+// registerNetworkSecrets registers the credentials of a network and its alert channels.
+func registerNetworkSecrets(n network) {
+	for _, rpc := range n.RPCs {
+		registerSecret(rpc)
+	}
+	registerSecret(n.RPCListURL)
+	registerSecret(n.ReferenceRPC)
+	for _, auth := range n.RPCAuth {
+		for _, value := range auth.Headers {
+			registerSecret(value)
+			// Also catch the token of an "Authorization: Bearer <token>" header on its own.
+			if fields := strings.Fields(value); len(fields) > 1 {
+				registerSecret(fields[len(fields)-1])
+			}
+		}
+		registerSecret(auth.Password)
+	}
+	registerNotifierSecrets(n.Notifier)
+}
+
+// registerNotifierSecrets registers the credentials of the alert channels of a notifier.
+func registerNotifierSecrets(n *notifier) {
+	registerSecret(n.TelegramBotToken)
+	for _, t := range parseAlertTargets(n.DiscordWebhook) {
+		registerSecret(t.Value)
+	}
+	registerSecret(n.SlackWebhook)
+	registerSecret(n.TeamsWebhook)
+	registerSecret(n.GoogleChatWebhook)
+	registerSecret(n.Mattermost.WebhookURL)
+	registerSecret(n.Matrix.AccessToken)
+	registerSecret(n.RocketChatWebhook)
+	registerSecret(n.Zulip.APIKey)
+	registerSecret(n.XMPP.Password)
+	registerSecret(n.IRC.NickServPassword)
+	registerSecret(n.MQTT.Password)
+	registerSecret(n.Kafka.Password)
+	registerSecret(n.NATS.Token)
+	registerSecret(n.NATS.Password)
+	registerSecret(n.PagerDuty.RoutingKey)
+	registerSecret(n.Opsgenie.APIKey)
+	registerSecret(n.Pushover.AppToken)
+	registerSecret(n.Pushover.UserKey)
+	registerSecret(n.Ntfy.Token)
+	registerSecret(n.Gotify.AppToken)
+	registerSecret(n.Twilio.AuthToken)
+	registerSecret(n.Webhook.URL)
+	registerSecret(n.Webhook.Secret)
+	registerSecret(n.Email.Password)
+	registerSecret(n.Email.OAuth2ClientSecret)
+	registerSecret(n.Email.OAuth2RefreshToken)
+	for _, t := range n.URLs {
+		registerSecret(t.url)
+		if u, err := url.Parse(t.url); err == nil {
+			registerSecret(u.User.Username())
+		}
+		registerNotifierSecrets(t.n)
+	}
+}
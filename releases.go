@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// releaseExcerptLength is the maximum length of the changelog excerpt in release announcements.
+const releaseExcerptLength = 600
+
+// changelogExcerpt shortens a release body to a readable excerpt.
+func changelogExcerpt(body string) string {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if len(body) <= releaseExcerptLength {
+		return body
+	}
+	excerpt := body[:releaseExcerptLength]
+	if i := strings.LastIndex(excerpt, "\n"); i > releaseExcerptLength/2 {
+		excerpt = excerpt[:i]
+	}
+	return strings.TrimSpace(excerpt) + "\n…"
+}
+
+// runReleaseAnnouncer polls go-livepeer releases and announces new ones to the given notifiers.
+// Releases that already exist when the watcher starts are not announced.
+func runReleaseAnnouncer(interval time.Duration, notifiers []*notifier) {
+	var latest [3]int
+	initialized := false
+	for {
+		releases, err := fetchGoLivepeerReleases()
+		if err != nil {
+			log.Printf("failed to fetch go-livepeer releases: %v", err)
+		}
+		// Announce oldest first so channels read in release order.
+		for i := len(releases) - 1; i >= 0; i-- {
+			r := releases[i]
+			v, ok := parseVersion(r.TagName)
+			if !ok || compareVersions(v, latest) <= 0 {
+				continue
+			}
+			latest = v
+			if !initialized {
+				continue
+			}
+			msg := fmt.Sprintf(tr("🚀 New go-livepeer release [%s](%s) published."), r.TagName, r.HTMLURL)
+			if excerpt := changelogExcerpt(r.Body); excerpt != "" {
+				msg += "\n\n" + excerpt
+			}
+			log.Printf("New go-livepeer release %s published", r.TagName)
+			for _, n := range notifiers {
+				n.send("release", msg, 0x0099FF)
+			}
+		}
+		if err == nil {
+			initialized = true
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// unconfirmedLog is a live event waiting for --confirmations blocks, and with --quorum for
+// enough RPC endpoints to agree on it, before it is handled.
+type unconfirmedLog struct {
+	log    types.Log
+	handle func(types.Log)
+	quorum quorumState
+}
+
+// handledReward identifies the Reward event that marked an orchestrator's reward as called, so
+// it can be undone if the event is reorged out.
+type handledReward struct {
+	tx     common.Hash
+	index  uint
+	round  uint64
+	minted *big.Int
+}
+
+// queueLog handles an event once it has --confirmations blocks on top of it, and undoes or drops
+// events the subscription reports as removed by a reorg.
+func (w *watcher) queueLog(vLog types.Log, handle func(types.Log)) {
+	if vLog.Removed {
+		w.handleRemovedLog(vLog)
+		return
+	}
+	if w.opts.confirmations == 0 && w.opts.quorum <= 1 {
+		handle(vLog)
+		return
+	}
+	w.unconfirmed = append(w.unconfirmed, unconfirmedLog{log: vLog, handle: handle})
+}
+
+// confirmLogs handles the queued events that reached the confirmation depth at head, in arrival
+// order, and reports whether there were any.
+func (w *watcher) confirmLogs(head uint64) bool {
+	var waiting []unconfirmedLog
+	handled := false
+	for _, u := range w.unconfirmed {
+		if u.log.BlockNumber+w.opts.confirmations > head {
+			waiting = append(waiting, u)
+			continue
+		}
+		if w.opts.quorum > 1 {
+			reached, drop := w.quorumReached(&u)
+			if drop {
+				continue
+			}
+			if !reached {
+				waiting = append(waiting, u)
+				continue
+			}
+		}
+		u.handle(u.log)
+		handled = true
+	}
+	w.unconfirmed = waiting
+	return handled
+}
+
+// handleRemovedLog drops a reorged-out event that is still waiting for confirmations. A Reward
+// event that was already handled re-arms the missed-reward warning for the orchestrator.
+func (w *watcher) handleRemovedLog(vLog types.Log) {
+	for i, u := range w.unconfirmed {
+		if u.log.TxHash == vLog.TxHash && u.log.Index == vLog.Index {
+			w.unconfirmed = append(w.unconfirmed[:i], w.unconfirmed[i+1:]...)
+			w.log.Printf("Dropped unconfirmed event of tx %s removed by a reorg", vLog.TxHash.Hex())
+			return
+		}
+	}
+	for _, o := range w.orchestrators {
+		r := o.handledReward
+		if r == nil || r.tx != vLog.TxHash || r.index != vLog.Index {
+			continue
+		}
+		o.handledReward = nil
+		if r.round != w.currentRound {
+			return
+		}
+		o.rewardCalled = false
+		o.sentWarning = false
+		o.rewardTime = time.Time{}
+		if r.minted != nil {
+			o.roundMinted.Sub(o.roundMinted, r.minted)
+		}
+		txHash := vLog.TxHash.Hex()
+		msg := fmt.Sprintf(
+			tr("⚠️ The reward call of %s in round %d ([tx %s](https://arbiscan.io/tx/%s)) was removed by a chain reorg. Reward is no longer called and the missed-reward warning is re-armed."),
+			o.link(), w.currentRound, txHash, txHash)
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "reward_reorged", msg, 0xFF0000)
+		return
+	}
+	w.log.Printf("Event of tx %s in block %d was removed by a reorg", vLog.TxHash.Hex(), vLog.BlockNumber)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxScannedTxs limits how many new transactions of a reward caller are inspected per scan.
+const maxScannedTxs = 10
+
+// callerTxCursor is the last confirmed nonce of a reward caller and the block it was read at.
+type callerTxCursor struct {
+	nonce uint64
+	block uint64
+}
+
+// blockTx is the part of a transaction returned by eth_getBlockByNumber the scan needs. Blocks
+// are decoded loosely because ethclient rejects Arbitrum's own transaction types.
+type blockTx struct {
+	Hash  common.Hash     `json:"hash"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Nonce hexutil.Uint64  `json:"nonce"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// checkRevertedTxs alerts when a reward caller sent a transaction to the BondingManager that
+// reverted, so a failing reward script is caught right away instead of by the missed-reward
+// warning. Rather than fetching every block, it watches the callers' confirmed nonces and
+// looks up the block of each new transaction.
+func (w *watcher) checkRevertedTxs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		w.log.Printf("failed to fetch block number: %v", err)
+		return
+	}
+	checked := make(map[common.Address]bool)
+	for _, o := range w.orchestrators {
+		caller := o.rewardCaller
+		if checked[caller] {
+			continue
+		}
+		checked[caller] = true
+		nonce, err := w.client.NonceAt(ctx, caller, new(big.Int).SetUint64(head))
+		if err != nil {
+			w.log.Printf("failed to fetch nonce of reward caller %s: %v", caller.Hex(), err)
+			continue
+		}
+		cursor, ok := w.callerTxs[caller]
+		if !ok || nonce < cursor.nonce {
+			w.callerTxs[caller] = callerTxCursor{nonce: nonce, block: head}
+			continue
+		}
+		from := cursor.block
+		for n := cursor.nonce; n < nonce && n < cursor.nonce+maxScannedTxs; n++ {
+			block, err := w.nonceBlock(ctx, caller, n, from, head)
+			if err != nil {
+				w.log.Printf("failed to find the transaction of reward caller %s with nonce %d: %v", caller.Hex(), n, err)
+				break
+			}
+			w.inspectCallerTx(ctx, o, block, n)
+			from = block
+		}
+		w.callerTxs[caller] = callerTxCursor{nonce: nonce, block: head}
+	}
+}
+
+// nonceBlock returns the block in (from, to] that included the caller's transaction with the
+// given nonce, by binary search over the caller's nonce.
+func (w *watcher) nonceBlock(ctx context.Context, caller common.Address, nonce, from, to uint64) (uint64, error) {
+	lo, hi := from+1, to
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		n, err := w.client.NonceAt(ctx, caller, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+		if n > nonce {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// inspectCallerTx alerts when the caller's transaction with the given nonce in a block was a
+// reverted call to the BondingManager.
+func (w *watcher) inspectCallerTx(ctx context.Context, o *orchestrator, block, nonce uint64) {
+	var result struct {
+		Transactions []blockTx `json:"transactions"`
+	}
+	if err := w.client.Client().CallContext(ctx, &result, "eth_getBlockByNumber", hexutil.EncodeUint64(block), true); err != nil {
+		w.log.Printf("failed to fetch block %d: %v", block, err)
+		return
+	}
+	for _, tx := range result.Transactions {
+		if tx.From != o.rewardCaller || uint64(tx.Nonce) != nonce {
+			continue
+		}
+		if tx.To == nil || *tx.To != w.net.Contracts.BondingManager {
+			return
+		}
+		receipt, err := w.client.TransactionReceipt(ctx, tx.Hash)
+		if err != nil {
+			w.log.Printf("failed to fetch receipt of %s: %v", tx.Hash.Hex(), err)
+			return
+		}
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			return
+		}
+		method := "unknown"
+		if len(tx.Input) >= 4 {
+			if m, err := w.abis.BondingManager.MethodById(tx.Input[:4]); err == nil {
+				method = m.Name
+			}
+		}
+		hash := tx.Hash.Hex()
+		msg := fmt.Sprintf(
+			tr("❌ Transaction [%s](https://arbiscan.io/tx/%s) from reward caller %s calling %s() on the BondingManager reverted: %s."),
+			hash, hash, o.rewardCaller.Hex(), method, w.replayRevertReason(ctx, tx, receipt, block))
+		w.log.Println(msg)
+		w.orchestratorAlert(o, "reward_tx_reverted", msg, 0xFF0000)
+		return
+	}
+}
+
+// replayRevertReason replays a reverted transaction on the state before its block to recover
+// the revert reason, which receipts don't carry.
+func (w *watcher) replayRevertReason(ctx context.Context, tx blockTx, receipt *types.Receipt, block uint64) string {
+	if receipt.GasUsed >= uint64(tx.Gas) {
+		return tr("out of gas")
+	}
+	msg := ethereum.CallMsg{From: tx.From, To: tx.To, Gas: uint64(tx.Gas), Data: tx.Input}
+	if _, err := w.client.CallContract(ctx, msg, new(big.Int).SetUint64(block-1)); err != nil {
+		return revertReason(err)
+	}
+	return tr("unknown reason")
+}
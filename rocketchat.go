@@ -0,0 +1,33 @@
+package main
+
+import "fmt"
+
+// sendRocketChatAlert posts an alert to a Rocket.Chat incoming webhook as an attachment colored
+// like the Discord embed.
+func sendRocketChatAlert(webhookURL, message string, color int, brand branding) error {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	text := message
+	if brand.Footer != "" {
+		text += "\n_" + brand.Footer + "_"
+	}
+	payload := map[string]interface{}{
+		"attachments": []map[string]interface{}{{
+			"title": title,
+			"text":  text,
+			"color": fmt.Sprintf("#%06X", color),
+		}},
+	}
+	if brand.Username != "" {
+		payload["alias"] = brand.Username
+	}
+	if brand.AvatarURL != "" {
+		payload["avatar"] = brand.AvatarURL
+	}
+	if err := postJSON(webhookURL, payload); err != nil {
+		return fmt.Errorf("rocket.chat webhook: %v", err)
+	}
+	return nil
+}
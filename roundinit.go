@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// roundInitCheckInterval is how often the RoundsManager is polled for an uninitialized round.
+const roundInitCheckInterval = 5 * time.Minute
+
+// maxInitializeRoundAttempts limits the initializeRound() transactions the watcher submits per round.
+const maxInitializeRoundAttempts = 3
+
+// roundInitState tracks the alert and the watcher's own initializeRound() transaction for a
+// round that started on L1 but wasn't initialized.
+type roundInitState struct {
+	round   uint64
+	alerted bool
+	// pending is set while the watcher's transaction waits to be mined; attempts counts the sent ones.
+	pending       bool
+	attempts      int
+	submitAlerted bool
+}
+
+// checkRoundInitialized alerts when a new round has started on L1 but nobody called
+// initializeRound() for longer than --uninitialized-round-delay, since reward can't be called
+// until then, and again once the round is initialized. With --auto-initialize-round the watcher
+// initializes the round itself once it stays uninitialized that long.
+func (w *watcher) checkRoundInitialized() {
+	values, err := w.callContract(w.abis.RoundsManager, w.net.Contracts.RoundsManager, "currentRoundInitialized")
+	if err != nil || len(values) == 0 {
+		w.log.Printf("failed to read whether the current round is initialized: %v", err)
+		return
+	}
+	if initialized, _ := values[0].(bool); initialized {
+		if w.roundInit.alerted {
+			msg := fmt.Sprintf(tr("✅ Round %d has been initialized, reward can be called again."), w.roundInit.round)
+			w.log.Println(msg)
+			w.roundAlert("round_initialized", msg, 0x00FF00)
+		}
+		w.roundInit = roundInitState{}
+		return
+	}
+	round, err := w.callRoundsManager("currentRound")
+	if err != nil {
+		w.log.Printf("failed to read current round: %v", err)
+		return
+	}
+	if w.roundInit.round != round.Uint64() {
+		w.roundInit = roundInitState{round: round.Uint64()}
+	}
+	startBlock, err := w.callRoundsManager("currentRoundStartBlock")
+	if err != nil {
+		w.log.Printf("failed to read current round start block: %v", err)
+		return
+	}
+	blockNum, err := w.callRoundsManager("blockNum")
+	if err != nil {
+		w.log.Printf("failed to read RoundsManager block number: %v", err)
+		return
+	}
+	blocks := new(big.Int).Sub(blockNum, startBlock)
+	if !blocks.IsInt64() {
+		return
+	}
+	elapsed := time.Duration(blocks.Int64()) * l1BlockTime
+	if delay := w.opts.uninitializedRoundDelay; delay > 0 && elapsed >= delay && !w.roundInit.alerted {
+		w.roundInit.alerted = true
+		msg := fmt.Sprintf(
+			tr("⚠️ Round %d started %s ago on L1 but has not been initialized yet, so no orchestrator can call reward. Anyone can call initializeRound() on the RoundsManager."),
+			w.roundInit.round, formatDuration(elapsed.Round(time.Minute)))
+		w.log.Println(msg)
+		w.alert("round_uninitialized", msg, 0xFFA500)
+	}
+	if delay := w.opts.autoInitializeRound; delay > 0 && elapsed >= delay && !w.roundInit.pending && w.roundInit.attempts < maxInitializeRoundAttempts {
+		w.initializeRound()
+	}
+}
+
+// initializeRound submits initializeRound() from the watcher's account and reports the outcome.
+// A failed submission is retried on the next check and only alerted once per round. A sent
+// transaction that fails is retried as well, up to maxInitializeRoundAttempts per round.
+func (w *watcher) initializeRound() {
+	round := w.roundInit.round
+	tx, err := w.submitTx(w.net.Contracts.RoundsManager, w.abis.RoundsManager, "initializeRound", func(receipt *types.Receipt, err error) {
+		w.reportInitializeRound(round, receipt, err)
+	})
+	if err != nil {
+		w.log.Printf("failed to submit initializeRound() for round %d: %v", round, err)
+		if !w.roundInit.submitAlerted {
+			w.roundInit.submitAlerted = true
+			msg := fmt.Sprintf(tr("❌ Failed to submit initializeRound() for round %d: %v"), round, err)
+			w.alert("round_initialize_failed", msg, 0xFF0000)
+		}
+		return
+	}
+	w.roundInit.pending = true
+	w.roundInit.attempts++
+	w.log.Printf("Submitted initializeRound() for round %d in tx %s", round, tx.Hex())
+}
+
+// reportInitializeRound alerts with the result of the watcher's initializeRound() transaction.
+func (w *watcher) reportInitializeRound(round uint64, receipt *types.Receipt, err error) {
+	if w.roundInit.round == round {
+		w.roundInit.pending = false
+	}
+	if err != nil {
+		msg := fmt.Sprintf(tr("❌ The initializeRound() transaction for round %d was not mined: %v"), round, err)
+		w.log.Println(msg)
+		w.alert("round_initialize_failed", msg, 0xFF0000)
+		return
+	}
+	tx := receipt.TxHash.Hex()
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		msg := fmt.Sprintf(tr("❌ The initializeRound() transaction [%s](https://arbiscan.io/tx/%s) for round %d reverted."), tx, tx, round)
+		w.log.Println(msg)
+		w.alert("round_initialize_failed", msg, 0xFF0000)
+		return
+	}
+	msg := fmt.Sprintf(tr("✅ The watcher initialized round %d in transaction [%s](https://arbiscan.io/tx/%s)."), round, tx, tx)
+	w.log.Println(msg)
+	w.roundAlert("round_initialize_tx", msg, 0x00FF00)
+}
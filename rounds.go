@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// l1BlockTime is the Ethereum block time. Livepeer rounds on Arbitrum are measured in L1 blocks.
+const l1BlockTime = 12 * time.Second
+
+// syncCurrentRound reads the current round from the RoundsManager when no NewRound event has
+// been seen yet, so warnings work from the first minute instead of after the next round starts.
+// The round start time is estimated from the blocks elapsed since the round's start block, and
+// the last reward round of every orchestrator tells whether reward was already called, so a
+// restart after the reward call doesn't warn about a missing reward.
+func (w *watcher) syncCurrentRound() {
+	if w.currentRound != 0 {
+		return
+	}
+	round, err := w.callRoundsManager("currentRound")
+	if err != nil {
+		w.log.Printf("failed to read current round: %v", err)
+		return
+	}
+	startBlock, err := w.callRoundsManager("currentRoundStartBlock")
+	if err != nil {
+		w.log.Printf("failed to read current round start block: %v", err)
+		return
+	}
+	blockNum, err := w.callRoundsManager("blockNum")
+	if err != nil {
+		w.log.Printf("failed to read RoundsManager block number: %v", err)
+		return
+	}
+	if !round.IsUint64() || round.Sign() == 0 {
+		return
+	}
+	w.currentRound = round.Uint64()
+	w.roundStart = time.Now()
+	if elapsed := new(big.Int).Sub(blockNum, startBlock); elapsed.Sign() > 0 && elapsed.IsInt64() {
+		w.roundStart = w.roundStart.Add(-time.Duration(elapsed.Int64()) * l1BlockTime)
+	}
+	w.log.Printf("Current round is %d, started around %s", w.currentRound, w.roundStart.UTC().Format(time.RFC3339))
+	for _, o := range w.orchestrators {
+		values, err := w.callContract(w.abis.BondingManager, w.net.Contracts.BondingManager, "getTranscoder", o.address)
+		if err != nil {
+			w.log.Printf("failed to fetch transcoder info of %s: %v", o.address.Hex(), err)
+			continue
+		}
+		w.syncLastRewardRound(o, values)
+	}
+}
+
+// syncLastRewardRound records the last reward round from the getTranscoder outputs of an
+// orchestrator, marking reward as called when it is the current round.
+func (w *watcher) syncLastRewardRound(o *orchestrator, values []interface{}) {
+	last, err := outputBigInt(w.abis.BondingManager, "getTranscoder", values, "lastRewardRound")
+	if err != nil || !last.IsUint64() {
+		return
+	}
+	if last.Uint64() > o.lastRewardRound {
+		o.lastRewardRound = last.Uint64()
+	}
+	if w.currentRound != 0 && last.Uint64() == w.currentRound {
+		o.rewardCalled = true
+	}
+}
+
+// roundBlocks is the L1 block range of a round.
+type roundBlocks struct {
+	round      uint64
+	startBlock *big.Int
+	length     *big.Int
+}
+
+// roundPosition returns how far the current round has progressed in L1 blocks, which unlike
+// wall-clock time follows the actual round timing. The round's start block and length are read
+// once per round.
+func (w *watcher) roundPosition() (roundPosition, bool) {
+	if w.roundBlocks.round != w.currentRound {
+		startBlock, err := w.callRoundsManager("currentRoundStartBlock")
+		if err != nil {
+			w.log.Printf("failed to read current round start block: %v", err)
+			return roundPosition{}, false
+		}
+		length, err := w.callRoundsManager("roundLength")
+		if err != nil {
+			w.log.Printf("failed to read round length: %v", err)
+			return roundPosition{}, false
+		}
+		if length.Sign() == 0 {
+			return roundPosition{}, false
+		}
+		w.roundBlocks = roundBlocks{round: w.currentRound, startBlock: startBlock, length: length}
+	}
+	blockNum, err := w.callRoundsManager("blockNum")
+	if err != nil {
+		w.log.Printf("failed to read RoundsManager block number: %v", err)
+		return roundPosition{}, false
+	}
+	return roundPosition{
+		elapsed: new(big.Int).Sub(blockNum, w.roundBlocks.startBlock),
+		length:  w.roundBlocks.length,
+	}, true
+}
+
+// roundPosition is the number of L1 blocks elapsed in a round of length blocks.
+type roundPosition struct {
+	elapsed *big.Int
+	length  *big.Int
+}
+
+// progress returns the percentage of the round that has elapsed.
+func (p roundPosition) progress() float64 {
+	progress, _ := new(big.Float).Quo(new(big.Float).SetInt(p.elapsed), new(big.Float).SetInt(p.length)).Float64()
+	return 100 * progress
+}
+
+// remaining estimates the time until the round ends from the L1 blocks left.
+func (p roundPosition) remaining() time.Duration {
+	left := new(big.Int).Sub(p.length, p.elapsed)
+	if left.Sign() <= 0 || !left.IsInt64() {
+		return 0
+	}
+	return time.Duration(left.Int64()) * l1BlockTime
+}
+
+// callRoundsManager calls a uint256 getter of the RoundsManager without arguments.
+func (w *watcher) callRoundsManager(method string) (*big.Int, error) {
+	values, err := w.callContract(w.abis.RoundsManager, w.net.Contracts.RoundsManager, method)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s returned no value", method)
+	}
+	v, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%s returned %T", method, values[0])
+	}
+	return v, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The parts of the contract ABIs used by syncCurrentRound.
+const (
+	testRoundsManagerABI = `[
+		{"type":"function","name":"currentRound","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+		{"type":"function","name":"currentRoundStartBlock","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+		{"type":"function","name":"blockNum","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+	]`
+	testBondingManagerABI = `[
+		{"type":"function","name":"getTranscoder","inputs":[{"name":"_transcoder","type":"address"}],
+		 "outputs":[{"name":"lastRewardRound","type":"uint256"},{"name":"deactivationRound","type":"uint256"}],"stateMutability":"view"}
+	]`
+)
+
+// fakeContracts serves eth_call for the contract methods of the test ABIs.
+type fakeContracts struct {
+	abis []abi.ABI
+	// results maps method names to their return values; methods without results fail.
+	results map[string][]interface{}
+}
+
+func (f *fakeContracts) Call(ctx context.Context, args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+	data, err := hexutil.Decode(input)
+	if err != nil || len(data) < 4 {
+		return nil, fmt.Errorf("invalid call data %q", input)
+	}
+	for _, a := range f.abis {
+		method, err := a.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		results, ok := f.results[method.Name]
+		if !ok {
+			return nil, errors.New("execution reverted")
+		}
+		return method.Outputs.Pack(results...)
+	}
+	return nil, errors.New("unknown method")
+}
+
+func TestSyncCurrentRound(t *testing.T) {
+	roundsManager, err := abi.JSON(strings.NewReader(testRoundsManagerABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bondingManager, err := abi.JSON(strings.NewReader(testBondingManagerABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rounds := map[string][]interface{}{
+		"currentRound":           {big.NewInt(4000)},
+		"currentRoundStartBlock": {big.NewInt(19000000)},
+		"blockNum":               {big.NewInt(19000300)},
+	}
+	tests := []struct {
+		name             string
+		lastRewardRound  int64
+		transcoderFails  bool
+		wantRewardCalled bool
+	}{
+		{name: "restart after reward was called", lastRewardRound: 4000, wantRewardCalled: true},
+		{name: "reward not called yet", lastRewardRound: 3999},
+		{name: "transcoder unreadable", transcoderFails: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := map[string][]interface{}{}
+			for method, values := range rounds {
+				results[method] = values
+			}
+			if !tt.transcoderFails {
+				results["getTranscoder"] = []interface{}{big.NewInt(tt.lastRewardRound), maxFutureRound}
+			}
+			server := rpc.NewServer()
+			defer server.Stop()
+			if err := server.RegisterName("eth", &fakeContracts{abis: []abi.ABI{roundsManager, bondingManager}, results: results}); err != nil {
+				t.Fatal(err)
+			}
+			net := network{Orchestrators: []orchestratorConfig{{Address: common.HexToAddress("0x1")}}, Notifier: &notifier{}}
+			w := newWatcher(&options{}, net, &services{abis: &contractABIs{RoundsManager: roundsManager, BondingManager: bondingManager}})
+			w.client = ethclient.NewClient(rpc.DialInProc(server))
+
+			w.syncCurrentRound()
+
+			if w.currentRound != 4000 {
+				t.Errorf("current round = %d, want 4000", w.currentRound)
+			}
+			o := w.orchestrators[0]
+			if o.rewardCalled != tt.wantRewardCalled {
+				t.Errorf("reward called = %v, want %v", o.rewardCalled, tt.wantRewardCalled)
+			}
+			if !tt.transcoderFails && o.lastRewardRound != uint64(tt.lastRewardRound) {
+				t.Errorf("last reward round = %d, want %d", o.lastRewardRound, tt.lastRewardRound)
+			}
+		})
+	}
+}
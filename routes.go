@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// routeEventTypes maps the event types accepted in routing rules onto the alert types they cover.
+var routeEventTypes = map[string][]string{
+	"new-round":      {"new_round"},
+	"reward-success": {"reward"},
+	"reward-missing": {"reward_missed"},
+	"rpc":            {"rpc_*", "subscription_error"},
+}
+
+// expandRouteTypes replaces the event types in routes with the alert types they cover. Rules for
+// alert types take precedence over the event type that includes them.
+func expandRouteTypes(routes map[string][]string) map[string][]string {
+	if routes == nil {
+		return nil
+	}
+	expanded := make(map[string][]string, len(routes))
+	for kind, channels := range routes {
+		if _, ok := routeEventTypes[kind]; !ok {
+			expanded[kind] = channels
+		}
+	}
+	for kind, channels := range routes {
+		for _, alertType := range routeEventTypes[kind] {
+			if _, ok := expanded[alertType]; !ok {
+				expanded[alertType] = channels
+			}
+		}
+	}
+	return expanded
+}
+
+// parseRoutes parses alert routing rules of the form "type=channel,channel;type=channel". Types
+// are alert types or the event types of routeEventTypes. A type ending in "*" matches every alert
+// type with that prefix, and an empty channel list drops the alerts of that type.
+func parseRoutes(raw string) (map[string][]string, error) {
+	routes := make(map[string][]string)
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		kind, channels, ok := strings.Cut(rule, "=")
+		kind = strings.TrimSpace(kind)
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid route %q, expected type=channel[,channel...]", rule)
+		}
+		routes[kind] = splitCSV(channels)
+	}
+	if err := validateRoutes(routes); err != nil {
+		return nil, err
+	}
+	return expandRouteTypes(routes), nil
+}
+
+// validateRoutes checks that routes only refer to supported alert channels.
+func validateRoutes(routes map[string][]string) error {
+	for kind, channels := range routes {
+		for _, channel := range channels {
+			if channel == "pagerduty" {
+				continue
+			}
+			if err := validateChannels([]string{channel}); err != nil {
+				return fmt.Errorf("route %s: %v", kind, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchAlertType returns the rule for an alert type. Exact types take precedence over the longest
+// matching prefix rule, and ok is false when no rule matches.
+func matchAlertType[V any](rules map[string]V, kind string) (value V, ok bool) {
+	if value, ok := rules[kind]; ok {
+		return value, true
+	}
+	best := -1
+	for pattern, v := range rules {
+		prefix, wildcard := strings.CutSuffix(pattern, "*")
+		if wildcard && strings.HasPrefix(kind, prefix) && len(prefix) > best {
+			best, value, ok = len(prefix), v, true
+		}
+	}
+	return value, ok
+}
+
+// route returns the channels that receive alerts of the given type. ok is false when no rule
+// matches, in which case the alert goes to every channel.
+func (n *notifier) route(kind string) (channels []string, ok bool) {
+	return matchAlertType(n.Routes, kind)
+}
+
+// parseTelegramTopics parses the Telegram forum topics per alert type, of the form
+// "type=topic;type=topic", with the same wildcards as routes. "*=topic" sets the default topic.
+func parseTelegramTopics(raw string) (map[string]int, error) {
+	topics := make(map[string]int)
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		kind, topic, ok := strings.Cut(rule, "=")
+		kind = strings.TrimSpace(kind)
+		id, err := strconv.Atoi(strings.TrimSpace(topic))
+		if !ok || kind == "" || err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid topic rule %q, expected type=topic ID", rule)
+		}
+		topics[kind] = id
+	}
+	return topics, nil
+}
+
+// telegramTopic returns the Telegram forum topic for alerts of the given type, 0 for the chat's
+// general topic.
+func (n *notifier) telegramTopic(kind string) int {
+	topic, _ := matchAlertType(n.TelegramTopics, kind)
+	return topic
+}
+
+// routed reports whether alerts of the given type are routed to the channel.
+func (n *notifier) routed(channel, kind string) bool {
+	channels, ok := n.route(kind)
+	if !ok {
+		return true
+	}
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
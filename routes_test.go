@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			raw:  "",
+			want: map[string][]string{},
+		},
+		{
+			name: "alert types",
+			raw:  "new_round=discord; reward_missed=telegram, pagerduty ;rpc_*=telegram",
+			want: map[string][]string{
+				"new_round":     {"discord"},
+				"reward_missed": {"telegram", "pagerduty"},
+				"rpc_*":         {"telegram"},
+			},
+		},
+		{
+			name: "event types",
+			raw:  "new-round=discord;reward-missing=telegram,pagerduty;rpc=telegram;reward-success=",
+			want: map[string][]string{
+				"new_round":          {"discord"},
+				"reward_missed":      {"telegram", "pagerduty"},
+				"rpc_*":              {"telegram"},
+				"subscription_error": {"telegram"},
+				"reward":             nil,
+			},
+		},
+		{
+			name: "alert type wins over event type",
+			raw:  "rpc=telegram;rpc_failed=pagerduty",
+			want: map[string][]string{
+				"rpc_*":              {"telegram"},
+				"subscription_error": {"telegram"},
+				"rpc_failed":         {"pagerduty"},
+			},
+		},
+		{name: "missing channels separator", raw: "reward", wantErr: true},
+		{name: "missing type", raw: "=discord", wantErr: true},
+		{name: "unknown channel", raw: "reward=fax", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRoutes(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRoutes(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRoutes(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouted(t *testing.T) {
+	routes, err := parseRoutes("rpc=telegram;rpc_failed=pagerduty;reward-success=;*=discord")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &notifier{Routes: routes}
+	tests := []struct {
+		channel, kind string
+		want          bool
+	}{
+		{"telegram", "rpc_reconnected", true},
+		{"discord", "rpc_reconnected", false},
+		{"telegram", "subscription_error", true},
+		{"pagerduty", "rpc_failed", true},
+		{"telegram", "rpc_failed", false},
+		{"discord", "reward", false},
+		{"discord", "new_round", true},
+		{"telegram", "new_round", false},
+	}
+	for _, tt := range tests {
+		if got := n.routed(tt.channel, tt.kind); got != tt.want {
+			t.Errorf("routed(%q, %q) = %v, want %v", tt.channel, tt.kind, got, tt.want)
+		}
+	}
+}
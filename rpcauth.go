@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcAuthConfig holds the credentials sent to an RPC endpoint, e.g. a private node behind an
+// authenticating proxy, so they don't have to be embedded in its URL.
+type rpcAuthConfig struct {
+	// Headers are sent with every HTTP request and WebSocket handshake.
+	Headers map[string]string `json:"headers"`
+	// Username and Password set HTTP basic auth.
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// rpcAuth maps RPC endpoint URLs to their credentials. It is filled before the watchers start.
+var rpcAuth = make(map[string]rpcAuthConfig)
+
+// validate checks the credentials configured for an endpoint URL.
+func (a rpcAuthConfig) validate(rawURL string) error {
+	if u, err := url.Parse(rawURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid RPC URL %q in rpcAuth", maskRPCURL(rawURL))
+	}
+	for name := range a.Headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("empty header name for %s", maskRPCURL(rawURL))
+		}
+		if a.Username != "" && http.CanonicalHeaderKey(name) == "Authorization" {
+			return fmt.Errorf("%s sets both an Authorization header and basic auth", maskRPCURL(rawURL))
+		}
+	}
+	return nil
+}
+
+// header returns the HTTP headers to send for the credentials.
+func (a rpcAuthConfig) header() http.Header {
+	h := make(http.Header)
+	for name, value := range a.Headers {
+		h.Set(name, value)
+	}
+	if a.Username != "" {
+		h.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password)))
+	}
+	return h
+}
+
+// rpcDialOptions returns the client options for an RPC endpoint: the shared TLS settings and
+// its configured credentials. The wss:// variant of an https:// URL uses the URL's credentials.
+func rpcDialOptions(rawURL string) []rpc.ClientOption {
+	a, ok := rpcAuth[rawURL]
+	if rest, isWSS := strings.CutPrefix(rawURL, "wss://"); !ok && isWSS {
+		a, ok = rpcAuth["https://"+rest]
+	}
+	if !ok {
+		return rpcClientOptions
+	}
+	return append(append([]rpc.ClientOption(nil), rpcClientOptions...), rpc.WithHeaders(a.header()))
+}
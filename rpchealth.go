@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// rpcHealthAlpha weighs a new probe in the moving averages of latency and error rate.
+	rpcHealthAlpha = 0.3
+	// rpcSwitchMargin is how many points the best endpoint must score above the connected one
+	// before the watcher switches to it, so it doesn't flap between similar endpoints.
+	rpcSwitchMargin = 20
+)
+
+// rpcHealth is the probe history of an RPC endpoint.
+type rpcHealth struct {
+	client    *ethclient.Client
+	probes    int
+	latency   time.Duration
+	errorRate float64
+	head      uint64
+	lastError string
+}
+
+// rpcHealthStatus is the health of an RPC endpoint as reported on the status endpoint.
+type rpcHealthStatus struct {
+	URL         string  `json:"url"`
+	Score       float64 `json:"score"`
+	LatencyMs   int64   `json:"latencyMs"`
+	ErrorRate   float64 `json:"errorRate"`
+	BlockHeight uint64  `json:"blockHeight"`
+	LastError   string  `json:"lastError,omitempty"`
+}
+
+// score rates an endpoint from 0 to 100 by its error rate, latency and how many seconds its
+// chain head trails the best endpoint. Endpoints that were never probed score 50.
+func (h *rpcHealth) score(bestHead uint64) float64 {
+	if h == nil || h.probes == 0 {
+		return 50
+	}
+	score := 100 * (1 - h.errorRate)
+	// 10 points per 500ms of latency, at most 30.
+	score -= min(float64(h.latency.Milliseconds())/50, 30)
+	if bestHead > h.head {
+		// Arbitrum produces about 4 blocks per second; 1 point per second behind, at most 40.
+		score -= min(float64(bestHead-h.head)/4, 40)
+	}
+	return max(score, 0)
+}
+
+// probeRPC measures one eth_blockNumber round trip to an endpoint, dialing it if needed.
+func probeRPC(url string, h *rpcHealth) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	var head uint64
+	err := func() error {
+		if h.client == nil {
+			rc, err := rpc.DialOptions(ctx, url, rpcDialOptions(url)...)
+			if err != nil {
+				return err
+			}
+			h.client = ethclient.NewClient(rc)
+		}
+		var err error
+		head, err = h.client.BlockNumber(ctx)
+		return err
+	}()
+	latency := time.Since(start)
+	failed := 0.0
+	if err != nil {
+		failed = 1
+		h.lastError = redact(err.Error())
+		if h.client != nil {
+			h.client.Close()
+			h.client = nil
+		}
+	} else {
+		h.lastError = ""
+		h.head = head
+	}
+	if h.probes == 0 {
+		h.latency, h.errorRate = latency, failed
+	} else {
+		h.latency = time.Duration((1-rpcHealthAlpha)*float64(h.latency) + rpcHealthAlpha*float64(latency))
+		h.errorRate = (1-rpcHealthAlpha)*h.errorRate + rpcHealthAlpha*failed
+	}
+	h.probes++
+}
+
+// checkRPCHealth probes every configured RPC endpoint concurrently and switches to the
+// healthiest one when it scores clearly better than the connected endpoint.
+func (w *watcher) checkRPCHealth() {
+	var wg sync.WaitGroup
+	for _, url := range w.net.RPCs {
+		h := w.rpcHealth[url]
+		if h == nil {
+			h = &rpcHealth{}
+			w.rpcHealth[url] = h
+		}
+		wg.Add(1)
+		go func(url string, h *rpcHealth) {
+			defer wg.Done()
+			probeRPC(url, h)
+		}(url, h)
+	}
+	wg.Wait()
+
+	ranked := w.rankedRPCs()
+	if len(ranked) == 0 || w.rpcURL == "" || ranked[0] == w.rpcURL {
+		return
+	}
+	bestHead := w.bestRPCHead()
+	best, current := w.rpcHealth[ranked[0]].score(bestHead), w.rpcHealth[w.rpcURL].score(bestHead)
+	if best-current >= rpcSwitchMargin {
+		w.log.Printf("Switching from %s (score %.0f) to the healthier %s (score %.0f)", maskRPCURL(w.rpcURL), current, maskRPCURL(ranked[0]), best)
+		w.reconnectRequested = true
+	}
+}
+
+// bestRPCHead returns the highest block reported by any endpoint.
+func (w *watcher) bestRPCHead() uint64 {
+	var head uint64
+	for _, h := range w.rpcHealth {
+		head = max(head, h.head)
+	}
+	return head
+}
+
+// rankedRPCs returns the configured RPC endpoints from healthiest to least healthy, keeping the
+// configured order between equal scores. With --primary-check-interval, a healthy primary RPC
+// comes first regardless of its score.
+func (w *watcher) rankedRPCs() []string {
+	ranked := append([]string(nil), w.net.RPCs...)
+	if w.opts.rpcHealthInterval <= 0 {
+		return ranked
+	}
+	bestHead := w.bestRPCHead()
+	preferPrimary := w.opts.primaryCheckInterval > 0 && w.primaryHealthy(bestHead)
+	primary := w.primaryRPC()
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if preferPrimary && (ranked[i] == primary) != (ranked[j] == primary) {
+			return ranked[i] == primary
+		}
+		return w.rpcHealth[ranked[i]].score(bestHead) > w.rpcHealth[ranked[j]].score(bestHead)
+	})
+	return ranked
+}
+
+// rpcHealthStatuses returns the health of the configured RPC endpoints for the status endpoint.
+func (w *watcher) rpcHealthStatuses() []rpcHealthStatus {
+	if w.opts.rpcHealthInterval <= 0 {
+		return nil
+	}
+	bestHead := w.bestRPCHead()
+	statuses := make([]rpcHealthStatus, 0, len(w.net.RPCs))
+	for _, url := range w.net.RPCs {
+		s := rpcHealthStatus{URL: maskRPCURL(url), Score: w.rpcHealth[url].score(bestHead)}
+		if h := w.rpcHealth[url]; h != nil {
+			s.LatencyMs, s.ErrorRate, s.BlockHeight, s.LastError = h.latency.Milliseconds(), h.errorRate, h.head, h.lastError
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
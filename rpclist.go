@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var rpcListHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// fetchRPCList downloads an RPC endpoint list. It accepts a plain JSON array of URLs, an object
+// with an "rpcs" array, or a chainlist-style object with an "rpc" array of URLs or {"url": ...} entries.
+func fetchRPCList(listURL string) ([]string, error) {
+	resp, err := rpcListHTTPClient.Get(listURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RPC list request failed: HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse RPC list: %v", err)
+	}
+	if obj, ok := raw.(map[string]interface{}); ok {
+		if list, ok := obj["rpcs"]; ok {
+			raw = list
+		} else {
+			raw = obj["rpc"]
+		}
+	}
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("RPC list contains no endpoint array")
+	}
+	var rpcs []string
+	for _, e := range entries {
+		var u string
+		switch v := e.(type) {
+		case string:
+			u = v
+		case map[string]interface{}:
+			u, _ = v["url"].(string)
+		}
+		// Skip chainlist templates that need an API key substituted.
+		if u == "" || strings.Contains(u, "${") {
+			continue
+		}
+		rpcs = append(rpcs, u)
+	}
+	if len(rpcs) == 0 {
+		return nil, fmt.Errorf("RPC list contains no usable endpoints")
+	}
+	return rpcs, nil
+}
+
+// refreshRPCList replaces the watcher's RPC endpoints with the remote list and requests a
+// reconnect when the endpoint in use was removed from it.
+func (w *watcher) refreshRPCList() {
+	rpcs, err := fetchRPCList(w.net.RPCListURL)
+	if err != nil {
+		w.log.Printf("failed to refresh RPC list, keeping %d known endpoint(s): %v", len(w.net.RPCs), err)
+		return
+	}
+	for _, rpc := range rpcs {
+		registerSecret(rpc)
+	}
+	w.net.RPCs = rpcs
+	w.rpcListUpdated = time.Now()
+	if w.rpcURL == "" {
+		return
+	}
+	for _, rpc := range rpcs {
+		if rpc == w.rpcURL {
+			return
+		}
+	}
+	w.log.Printf("RPC %s was removed from the RPC list, reconnecting", maskRPCURL(w.rpcURL))
+	w.reconnectRequested = true
+}
@@ -56,8 +56,10 @@ func downloadABI(contractName, outputPath string) error {
 
 func main() {
 	contracts := map[string]string{
-		"BondingManagerTarget": "../ABIs/BondingManager.json",
-		"RoundsManagerTarget":  "../ABIs/RoundsManager.json",
+		"BondingManagerTarget":  "../ABIs/BondingManager.json",
+		"RoundsManagerTarget":   "../ABIs/RoundsManager.json",
+		"TicketBrokerTarget":    "../ABIs/TicketBroker.json",
+		"ServiceRegistryTarget": "../ABIs/ServiceRegistry.json",
 	}
 
 	fmt.Println("Downloading Livepeer protocol ABIs...")
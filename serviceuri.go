@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+// serviceURIState tracks the last reachability check of the orchestrator's ServiceURI.
+type serviceURIState struct {
+	down           bool
+	warnedExpiry   time.Time
+	lastServiceURI string
+}
+
+// fetchServiceURI reads the orchestrator's ServiceURI from the ServiceRegistry.
+func (w *watcher) fetchServiceURI(o *orchestrator) (string, error) {
+	values, err := w.callContract(w.abis.ServiceRegistry, w.net.Contracts.ServiceRegistry, "getServiceURI", o.address)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("getServiceURI returned no value")
+	}
+	uri, _ := values[0].(string)
+	return uri, nil
+}
+
+// checkServiceURIs checks the ServiceURI of every orchestrator.
+func (w *watcher) checkServiceURIs() {
+	for _, o := range w.orchestrators {
+		w.checkServiceURI(o)
+	}
+}
+
+// checkServiceURI verifies the orchestrator's ServiceURI is reachable over TLS and its certificate
+// is not about to expire.
+func (w *watcher) checkServiceURI(o *orchestrator) {
+	uri, err := w.fetchServiceURI(o)
+	if err != nil {
+		w.log.Printf("failed to fetch ServiceURI of %s: %v", o.address.Hex(), err)
+		return
+	}
+	if uri != o.serviceURI.lastServiceURI {
+		w.log.Printf("Orchestrator %s ServiceURI: %s", o.address.Hex(), uri)
+		o.serviceURI.lastServiceURI = uri
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		w.reportServiceURIDown(o, uri, fmt.Errorf("invalid ServiceURI"))
+		return
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: !w.opts.serviceURIVerifyTLS, // go-livepeer uses self-signed certificates by default.
+	})
+	if err != nil {
+		w.reportServiceURIDown(o, uri, err)
+		return
+	}
+	certs := conn.ConnectionState().PeerCertificates
+	conn.Close()
+	if o.serviceURI.down {
+		upMsg := fmt.Sprintf(tr("✅ ServiceURI %s of %s is reachable again."), uri, o.link())
+		w.log.Println(upMsg)
+		w.orchestratorAlert(o, "service_uri_up", upMsg, 0x00FF00)
+		o.serviceURI.down = false
+	}
+	if len(certs) == 0 || w.opts.certExpiryWarning <= 0 {
+		return
+	}
+	notAfter := certs[0].NotAfter
+	if time.Until(notAfter) < w.opts.certExpiryWarning && !o.serviceURI.warnedExpiry.Equal(notAfter) {
+		expiryMsg := fmt.Sprintf(
+			tr("⚠️ TLS certificate of ServiceURI %s of %s expires on %s (in %s)."),
+			uri, o.link(), formatDate(notAfter), formatDuration(time.Until(notAfter).Round(time.Hour)))
+		if time.Now().After(notAfter) {
+			expiryMsg = fmt.Sprintf(tr("❌ TLS certificate of ServiceURI %s of %s expired on %s."), uri, o.link(), formatDate(notAfter))
+		}
+		w.log.Println(expiryMsg)
+		w.orchestratorAlert(o, "cert_expiry", expiryMsg, 0xFFA500)
+		o.serviceURI.warnedExpiry = notAfter
+	}
+}
+
+// reportServiceURIDown alerts once when the ServiceURI becomes unreachable.
+func (w *watcher) reportServiceURIDown(o *orchestrator, uri string, err error) {
+	w.log.Printf("ServiceURI %s unreachable: %v", uri, err)
+	if o.serviceURI.down {
+		return
+	}
+	downMsg := fmt.Sprintf(tr("❌ ServiceURI %s of %s is unreachable: %v. Broadcasters can't send jobs to it."), uri, o.link(), err)
+	w.orchestratorAlert(o, "service_uri_down", downMsg, 0xFF0000)
+	o.serviceURI.down = true
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alert severities, from least to most severe.
+const (
+	severityInfo     = "info"
+	severityWarning  = "warning"
+	severityCritical = "critical"
+)
+
+// severityRanks orders alert severities from least to most severe.
+var severityRanks = map[string]int{
+	severityInfo:     0,
+	severityWarning:  1,
+	severityCritical: 2,
+}
+
+// severityAtLeast reports whether severity is at least as severe as min.
+func severityAtLeast(severity, min string) bool {
+	return severityRanks[severity] >= severityRanks[min]
+}
+
+// alertSeverities assigns a severity to every alert type. Colors only style the alerts.
+var alertSeverities = map[string]string{
+	"rpc_failed":                    severityCritical,
+	"rpc_wrong_chain":               severityCritical,
+	"subscription_error":            severityCritical,
+	"reward_missed":                 severityCritical,
+	"reward_final_call":             severityCritical,
+	"round_initialize_failed":       severityCritical,
+	"auto_reward_failed":            severityCritical,
+	"reward_tx_reverted":            severityCritical,
+	"reward_tx_stuck":               severityCritical,
+	"reward_reorged":                severityCritical,
+	"auto_reward_submitted":         severityWarning,
+	"deactivated":                   severityCritical,
+	"service_uri_down":              severityCritical,
+	"network_reward_stall":          severityWarning,
+	"round_stall":                   severityWarning,
+	"round_uninitialized":           severityWarning,
+	"rpc_disagreement":              severityWarning,
+	"reward_tx_pending":             severityWarning,
+	"gas_anomaly":                   severityWarning,
+	"caller_tx_stuck":               severityWarning,
+	"caller_balance_low":            severityWarning,
+	"deactivation_scheduled":        severityWarning,
+	"delegator_claim_lag":           severityWarning,
+	"head_lag":                      severityWarning,
+	"node_outdated":                 severityWarning,
+	"cert_expiry":                   severityWarning,
+	"monitoring_started":            severityInfo,
+	"rpc_reconnected":               severityInfo,
+	"rpc_primary_restored":          severityInfo,
+	"network_reward_stall_resolved": severityInfo,
+	"reward":                        severityInfo,
+	"round_summary":                 severityInfo,
+	"new_round":                     severityInfo,
+	"caller_tx_mined":               severityInfo,
+	"caller_balance_restored":       severityInfo,
+	"delegator_claimed":             severityInfo,
+	"head_lag_resolved":             severityInfo,
+	"service_uri_up":                severityInfo,
+	"abi_refreshed":                 severityInfo,
+	"release":                       severityInfo,
+}
+
+// colorSeverity derives the severity of an alert of an unknown type from its color.
+func colorSeverity(color int) string {
+	switch color {
+	case 0xFF0000:
+		return severityCritical
+	case 0xFFA500:
+		return severityWarning
+	}
+	return severityInfo
+}
+
+// severity returns the severity of the alert: its explicit severity, else the severity of its type.
+func (a alert) severity() string {
+	if a.Severity != "" {
+		return a.Severity
+	}
+	if s, ok := alertSeverities[a.Type]; ok {
+		return s
+	}
+	return colorSeverity(a.Color)
+}
+
+// parseMinSeverities parses per-channel minimum severities of the form "channel=severity,...".
+// The channel "*" sets the minimum of every channel without its own entry.
+func parseMinSeverities(raw []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, entry := range raw {
+		channel, severity, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid minimum severity %q, expected channel=severity", entry)
+		}
+		out[strings.TrimSpace(channel)] = strings.TrimSpace(severity)
+	}
+	if err := validateMinSeverities(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// validateMinSeverities checks that minimum severities name known channels and severities.
+func validateMinSeverities(min map[string]string) error {
+	for channel, severity := range min {
+		if _, ok := severityRanks[severity]; !ok {
+			return fmt.Errorf("unknown severity %q for %s, expected info, warning or critical", severity, channel)
+		}
+		if channel == "*" {
+			continue
+		}
+		if err := validateChannels([]string{channel}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minSeverity returns the lowest severity the channel receives.
+func (n *notifier) minSeverity(channel string) string {
+	if min := n.MinSeverity[channel]; min != "" {
+		return min
+	}
+	if channel == "sms" {
+		// SMS only receives critical alerts unless configured otherwise.
+		if n.Twilio.MinSeverity != "" {
+			return n.Twilio.MinSeverity
+		}
+		return severityCritical
+	}
+	if min := n.MinSeverity["*"]; min != "" {
+		return min
+	}
+	return severityInfo
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// handleDumpSignal dumps the state of all watchers to the log whenever SIGUSR1 is received.
+func handleDumpSignal(watchers []*watcher, status *statusBoard) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	go func() {
+		for range sigCh {
+			dumpState(watchers, status)
+		}
+	}()
+}
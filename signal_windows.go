@@ -0,0 +1,6 @@
+//go:build windows
+
+package main
+
+// handleDumpSignal is a no-op on Windows, which has no SIGUSR1.
+func handleDumpSignal(watchers []*watcher, status *statusBoard) {}
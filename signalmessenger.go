@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// signalConfig configures the Signal alert channel, sent through a signal-cli REST API server
+// (github.com/bbernhard/signal-cli-rest-api).
+type signalConfig struct {
+	APIURL     string   `json:"apiUrl"`
+	Number     string   `json:"number"`
+	Recipients []string `json:"recipients"`
+}
+
+func (c signalConfig) complete() bool {
+	return c.APIURL != "" && c.Number != "" && len(c.Recipients) > 0
+}
+
+// sendSignalAlert sends an alert from the registered number to the recipients. Signal has no
+// link markup, so links are written out after their text.
+func sendSignalAlert(cfg signalConfig, message string) error {
+	payload := map[string]interface{}{
+		"message":    markdownLinkRe.ReplaceAllString(message, "$1 ($2)"),
+		"number":     cfg.Number,
+		"recipients": cfg.Recipients,
+	}
+	if err := postJSON(strings.TrimRight(cfg.APIURL, "/")+"/v2/send", payload); err != nil {
+		return fmt.Errorf("signal-cli REST API: %v", err)
+	}
+	return nil
+}
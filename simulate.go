@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rewardABI is the BondingManager reward() function, simulated to diagnose missed rewards.
+var rewardABI = mustParseABI(`[{"type":"function","name":"reward","stateMutability":"nonpayable","inputs":[],"outputs":[]}]`)
+
+// rewardRevertHints explain the BondingManager revert reasons of reward(), by substring.
+var rewardRevertHints = []struct{ match, hint string }{
+	{"not initialized", "The round has not been initialized yet; anyone can call initializeRound() on the RoundsManager."},
+	{"already called reward", "Reward was already called this round, but the watcher did not see the event; check the RPC."},
+	{"active transcoder", "The orchestrator is not in the active set this round, so it cannot call reward."},
+	{"paused", "The protocol is paused."},
+}
+
+// simulateReward runs an eth_call of reward() from the orchestrator and describes the outcome.
+func (w *watcher) simulateReward(o *orchestrator) string {
+	data, err := rewardABI.Pack("reward")
+	if err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	to := w.net.Contracts.BondingManager
+	_, err = w.client.CallContract(ctx, ethereum.CallMsg{From: o.address, To: &to, Data: data}, nil)
+	if err == nil {
+		return tr(" Simulating reward() succeeded, so the call would go through now; check that the reward caller is running and funded.")
+	}
+	reason := revertReason(err)
+	for _, h := range rewardRevertHints {
+		if strings.Contains(strings.ToLower(reason), h.match) {
+			return fmt.Sprintf(tr(" Simulating reward() reverts with %q. %s"), reason, tr(h.hint))
+		}
+	}
+	return fmt.Sprintf(tr(" Simulating reward() fails: %s."), reason)
+}
+
+// revertReason extracts the revert reason from a failed eth_call, falling back to the error text.
+func revertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return reason
+			}
+		}
+	}
+	return strings.TrimPrefix(err.Error(), "execution reverted: ")
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackMarkdown converts an alert message to Slack mrkdwn, turning markdown links into <url|text>.
+func slackMarkdown(message string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range markdownLinkRe.FindAllStringSubmatchIndex(message, -1) {
+		b.WriteString(slackEscaper.Replace(message[last:m[0]]))
+		text, link := message[m[2]:m[3]], message[m[4]:m[5]]
+		fmt.Fprintf(&b, "<%s|%s>", link, slackEscaper.Replace(text))
+		last = m[1]
+	}
+	b.WriteString(slackEscaper.Replace(message[last:]))
+	return b.String()
+}
+
+// sendSlackAlert posts a message to a Slack incoming webhook as Block Kit blocks inside an
+// attachment, whose color bar shows the alert severity.
+func sendSlackAlert(webhookURL, message string, color int, brand branding) error {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	blocks := []map[string]interface{}{
+		{"type": "header", "text": map[string]string{"type": "plain_text", "text": title}},
+		{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": slackMarkdown(message)}},
+	}
+	if brand.Footer != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type":     "context",
+			"elements": []map[string]string{{"type": "plain_text", "text": brand.Footer}},
+		})
+	}
+	payload := map[string]interface{}{
+		"text": message, // Notification fallback.
+		"attachments": []map[string]interface{}{{
+			"color":  fmt.Sprintf("#%06X", color),
+			"blocks": blocks,
+		}},
+	}
+	if brand.Username != "" {
+		payload["username"] = brand.Username
+	}
+	if brand.AvatarURL != "" {
+		payload["icon_url"] = brand.AvatarURL
+	}
+	return postJSON(webhookURL, payload)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauth2TokenURLs are the token endpoints of the SMTP providers that need XOAUTH2, by SMTP host.
+var oauth2TokenURLs = map[string]string{
+	"smtp.gmail.com":        "https://oauth2.googleapis.com/token",
+	"smtp.office365.com":    "https://login.microsoftonline.com/common/oauth2/v2.0/token",
+	"outlook.office365.com": "https://login.microsoftonline.com/common/oauth2/v2.0/token",
+}
+
+// oauth2Token is a cached access token, with the refresh token to renew it, which some
+// providers rotate.
+type oauth2Token struct {
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+}
+
+// oauth2Tokens caches access tokens by client ID and original refresh token.
+var oauth2Tokens = struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2Token
+}{tokens: make(map[string]*oauth2Token)}
+
+// usesOAuth2 reports whether the SMTP server is authenticated with XOAUTH2 instead of a password.
+func (c EmailConfig) usesOAuth2() bool {
+	return c.OAuth2RefreshToken != ""
+}
+
+// oauth2TokenURL returns the token endpoint, which defaults to the provider's for Gmail and Office 365.
+func (c EmailConfig) oauth2TokenURL() string {
+	if c.OAuth2TokenURL != "" {
+		return c.OAuth2TokenURL
+	}
+	return oauth2TokenURLs[strings.ToLower(c.Host)]
+}
+
+// oauth2AccessToken returns a valid access token, refreshing it shortly before it expires.
+func (c EmailConfig) oauth2AccessToken() (string, error) {
+	oauth2Tokens.mu.Lock()
+	defer oauth2Tokens.mu.Unlock()
+	key := c.OAuth2ClientID + "\x00" + c.OAuth2RefreshToken
+	token := oauth2Tokens.tokens[key]
+	if token == nil {
+		token = &oauth2Token{refreshToken: c.OAuth2RefreshToken}
+		oauth2Tokens.tokens[key] = token
+	}
+	if token.accessToken != "" && time.Until(token.expiry) > time.Minute {
+		return token.accessToken, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {c.OAuth2ClientID},
+		"client_secret": {c.OAuth2ClientSecret},
+		"refresh_token": {token.refreshToken},
+	}
+	if c.OAuth2Scope != "" {
+		form.Set("scope", c.OAuth2Scope)
+	}
+	resp, err := alertHTTPClient.PostForm(c.oauth2TokenURL(), form)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh SMTP OAuth2 token: %v", err)
+	}
+	defer resp.Body.Close()
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.AccessToken == "" {
+		if result.Error != "" {
+			return "", fmt.Errorf("failed to refresh SMTP OAuth2 token: %s: %s", result.Error, result.ErrorDescription)
+		}
+		return "", fmt.Errorf("failed to refresh SMTP OAuth2 token: HTTP %d", resp.StatusCode)
+	}
+	registerSecret(result.AccessToken)
+	token.accessToken = result.AccessToken
+	token.expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	if result.RefreshToken != "" {
+		registerSecret(result.RefreshToken)
+		token.refreshToken = result.RefreshToken
+	}
+	return token.accessToken, nil
+}
+
+// xoauth2Auth implements the XOAUTH2 SASL mechanism used by Gmail and Office 365.
+type xoauth2Auth struct {
+	username, token string
+}
+
+func (a xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS {
+		return "", nil, fmt.Errorf("refusing to send an OAuth2 token over an unencrypted connection")
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+func (a xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		// The server sent an error challenge; an empty response makes it return the final error.
+		return []byte{}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import "time"
+
+// stallDetector tracks when an event was last seen and reports when none arrived for too long.
+type stallDetector struct {
+	timeout time.Duration
+	last    time.Time
+	alerted bool
+}
+
+// newStallDetector creates a stall detector that starts counting from now.
+func newStallDetector(timeout time.Duration) *stallDetector {
+	return &stallDetector{timeout: timeout, last: time.Now()}
+}
+
+// seen records an event and reports whether it ended a previously alerted stall.
+func (d *stallDetector) seen() bool {
+	recovered := d.alerted
+	d.last = time.Now()
+	d.alerted = false
+	return recovered
+}
+
+// stalled reports whether the timeout was exceeded, only returning true once per stall.
+func (d *stallDetector) stalled() bool {
+	if d.timeout <= 0 || d.alerted || time.Since(d.last) < d.timeout {
+		return false
+	}
+	d.alerted = true
+	return true
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// persistedState is the part of a watcher's state that survives restarts.
+type persistedState struct {
+	CurrentRound        uint64                           `json:"currentRound"`
+	RoundStart          time.Time                        `json:"roundStart"`
+	LastProcessedBlock  uint64                           `json:"lastProcessedBlock"`
+	MonitoringAlertSent bool                             `json:"monitoringAlertSent"`
+	Orchestrators       map[string]persistedOrchestrator `json:"orchestrators"`
+	// DiscordThreads are the "Round N" threads by webhook ID and round, so a restart keeps posting in them.
+	DiscordThreads map[string]map[uint64]string `json:"discordThreads,omitempty"`
+}
+
+// persistedOrchestrator is the persisted round state of an orchestrator.
+type persistedOrchestrator struct {
+	RewardCalled bool `json:"rewardCalled"`
+	SentWarning  bool `json:"sentWarning"`
+	WarningStage int  `json:"warningStage,omitempty"`
+}
+
+// stateStore keeps the persisted state of all watchers in a JSON file, keyed by network name.
+type stateStore struct {
+	mu     sync.Mutex
+	path   string
+	states map[string]persistedState
+	last   []byte
+}
+
+// loadStateStore reads the state file at path. A missing file starts with an empty state.
+func loadStateStore(path string) (*stateStore, error) {
+	s := &stateStore{path: path, states: make(map[string]persistedState)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %v", err)
+	}
+	if err := json.Unmarshal(data, &s.states); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %v", path, err)
+	}
+	s.last = data
+	return s, nil
+}
+
+// get returns the persisted state of a network.
+func (s *stateStore) get(network string) (persistedState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[network]
+	return state, ok
+}
+
+// put stores the state of a network and rewrites the file when anything changed.
+func (s *stateStore) put(network string, state persistedState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[network] = state
+	data, err := json.MarshalIndent(s.states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if string(data) == string(s.last) {
+		return nil
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return err
+	}
+	s.last = data
+	return nil
+}
+
+// restoreState loads the watcher's persisted state, so a restart keeps the round, doesn't
+// repeat warnings and doesn't announce itself again.
+func (w *watcher) restoreState() {
+	if w.state == nil {
+		return
+	}
+	state, ok := w.state.get(w.net.Name)
+	if !ok {
+		return
+	}
+	w.currentRound = state.CurrentRound
+	w.roundStart = state.RoundStart
+	w.headLag.lastProcessed = state.LastProcessedBlock
+	w.sentInitialMonitoringAlert = state.MonitoringAlertSent
+	for _, o := range w.orchestrators {
+		if p, ok := state.Orchestrators[o.address.Hex()]; ok {
+			o.rewardCalled = p.RewardCalled
+			o.sentWarning = p.SentWarning
+			o.warningStage = p.WarningStage
+		}
+	}
+	for webhookID, threads := range state.DiscordThreads {
+		for round, id := range threads {
+			w.net.Notifier.threads.setDiscordThread(webhookID, round, id)
+		}
+	}
+	w.log.Printf("Restored state: round %d, last processed block %d", w.currentRound, w.headLag.lastProcessed)
+}
+
+// saveState persists the watcher's state.
+func (w *watcher) saveState() {
+	if w.state == nil {
+		return
+	}
+	state := persistedState{
+		CurrentRound:        w.currentRound,
+		RoundStart:          w.roundStart,
+		LastProcessedBlock:  w.headLag.lastProcessed,
+		MonitoringAlertSent: w.sentInitialMonitoringAlert,
+		Orchestrators:       make(map[string]persistedOrchestrator),
+		DiscordThreads:      w.net.Notifier.threads.discordThreadIDs(),
+	}
+	for _, o := range w.orchestrators {
+		state.Orchestrators[o.address.Hex()] = persistedOrchestrator{RewardCalled: o.rewardCalled, SentWarning: o.sentWarning, WarningStage: o.warningStage}
+	}
+	if err := w.state.put(w.net.Name, state); err != nil {
+		w.log.Printf("failed to write state file: %v", err)
+	}
+}
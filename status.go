@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// watcherStatus is a snapshot of a watcher's state for external monitors.
+type watcherStatus struct {
+	Network       string               `json:"network"`
+	Orchestrators []orchestratorStatus `json:"orchestrators"`
+	ConnectedRPC  string               `json:"connectedRpc"`
+	// RPCs are the health scores of the configured RPC endpoints, if they are probed.
+	RPCs          []rpcHealthStatus `json:"rpcs,omitempty"`
+	CurrentRound  uint64            `json:"currentRound"`
+	RoundStart    time.Time         `json:"roundStart"`
+	LastEventTime time.Time         `json:"lastEventTime"`
+	// LastProcessedBlock is the newest block seen through the subscriptions.
+	LastProcessedBlock uint64    `json:"lastProcessedBlock"`
+	LastAlertTime      time.Time `json:"lastAlertTime"`
+	LastAlertError     string    `json:"lastAlertError,omitempty"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// orchestratorStatus is the status of a single orchestrator of a watcher.
+type orchestratorStatus struct {
+	Address      string `json:"address"`
+	RewardCalled bool   `json:"rewardCalled"`
+	// LastRewardRound is the last round reward was called in; the time and tx are only known
+	// for reward calls seen by the watcher.
+	LastRewardRound uint64    `json:"lastRewardRound,omitempty"`
+	LastRewardTime  time.Time `json:"lastRewardTime"`
+	LastRewardTx    string    `json:"lastRewardTx,omitempty"`
+}
+
+// statusBoard collects the latest status of every watcher and optionally mirrors it to a file.
+type statusBoard struct {
+	mu       sync.Mutex
+	path     string
+	statuses map[string]watcherStatus
+}
+
+// newStatusBoard creates a status board; path may be empty to skip writing a status file.
+func newStatusBoard(path string) *statusBoard {
+	return &statusBoard{path: path, statuses: make(map[string]watcherStatus)}
+}
+
+// update stores a watcher's status and rewrites the status file.
+func (b *statusBoard) update(s watcherStatus) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.statuses[s.Network] = s
+	if b.path == "" {
+		return nil
+	}
+	return writeFileAtomic(b.path, b.snapshotJSON())
+}
+
+// snapshotJSON encodes the statuses: a single object for one network, keyed by network otherwise.
+// The caller must hold b.mu.
+func (b *statusBoard) snapshotJSON() []byte {
+	var v interface{} = b.statuses
+	if len(b.statuses) == 1 {
+		for _, s := range b.statuses {
+			v = s
+		}
+	}
+	data, _ := json.MarshalIndent(v, "", "  ")
+	return append(data, '\n')
+}
+
+// writeFileAtomic writes data to a temporary file and renames it over path, so readers never
+// see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// publishStatus pushes the watcher's current state to the status board.
+func (w *watcher) publishStatus() {
+	if w.status == nil {
+		return
+	}
+	if err := w.status.update(w.snapshot()); err != nil {
+		w.log.Printf("failed to write status file: %v", err)
+	}
+}
+
+// snapshot returns the watcher's current status.
+func (w *watcher) snapshot() watcherStatus {
+	orchestrators := make([]orchestratorStatus, 0, len(w.orchestrators))
+	for _, o := range w.orchestrators {
+		orchestrators = append(orchestrators, orchestratorStatus{
+			Address:         o.address.Hex(),
+			RewardCalled:    o.rewardCalled,
+			LastRewardRound: o.lastRewardRound,
+			LastRewardTime:  o.lastRewardTime,
+			LastRewardTx:    o.lastRewardTx,
+		})
+	}
+	return watcherStatus{
+		Network:            w.net.Name,
+		Orchestrators:      orchestrators,
+		ConnectedRPC:       w.connectedRPC,
+		RPCs:               w.rpcHealthStatuses(),
+		CurrentRound:       w.currentRound,
+		RoundStart:         w.roundStart,
+		LastEventTime:      w.lastEventTime,
+		LastProcessedBlock: w.headLag.lastProcessed,
+		LastAlertTime:      w.lastAlertTime,
+		LastAlertError:     w.lastAlertError,
+		UpdatedAt:          time.Now(),
+	}
+}
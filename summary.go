@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// transcoderTotalStake returns the orchestrator's current total stake, or nil if it can't be read.
+func (w *watcher) transcoderTotalStake(o *orchestrator) *big.Int {
+	values, err := w.callContract(w.abis.BondingManager, w.net.Contracts.BondingManager, "transcoderTotalStake", o.address)
+	if err != nil {
+		w.log.Printf("failed to read total stake of %s: %v", o.address.Hex(), err)
+		return nil
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	stake, _ := values[0].(*big.Int)
+	return stake
+}
+
+// roundSummary describes the round that just ended: whether and when reward was called, the
+// LPT minted, fees redeemed, treasury contribution and the change in stake over the round.
+func (w *watcher) roundSummary(o *orchestrator) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, tr("📊 Round %d summary for %s:"), w.currentRound, o.link())
+	if o.rewardCalled {
+		b.WriteString(tr("\n✅ Reward called"))
+		if !o.rewardTime.IsZero() && !w.roundStart.IsZero() {
+			fmt.Fprintf(&b, tr(" %s after the round started"), formatDuration(o.rewardTime.Sub(w.roundStart).Round(time.Minute)))
+		}
+		if o.roundMinted.Sign() > 0 {
+			fmt.Fprintf(&b, tr(", %s LPT minted"), formatUnits(o.roundMinted, 18, 4))
+		}
+		if o.roundRewardFee.Sign() > 0 {
+			fmt.Fprintf(&b, tr(", %s ETH in transaction fees"), formatUnits(o.roundRewardFee, 18, 6))
+		}
+		b.WriteString(tr("."))
+	} else {
+		b.WriteString(tr("\n❌ Reward was not called."))
+	}
+	fmt.Fprintf(&b, tr("\n💰 %s ETH in fees earned from %d redeemed winning ticket(s)."), formatUnits(o.roundFees, 18, 6), o.roundTickets)
+	if o.treasuryTotal.Sign() > 0 {
+		fmt.Fprintf(&b,
+			tr("\n🏛 Treasury contribution: %s LPT this round, %s LPT since the watcher started."),
+			formatUnits(o.roundTreasury, 18, 4), formatUnits(o.treasuryTotal, 18, 4))
+	}
+	if o.roundStartStake != nil {
+		if stake := w.transcoderTotalStake(o); stake != nil {
+			delta := new(big.Int).Sub(stake, o.roundStartStake)
+			sign := "+"
+			if delta.Sign() < 0 {
+				sign = "-"
+				delta.Neg(delta)
+			}
+			fmt.Fprintf(&b, tr("\n📈 Stake: %s%s LPT (total %s LPT)."), sign, formatUnits(delta, 18, 4), formatUnits(stake, 18, 4))
+		}
+	}
+	return b.String()
+}
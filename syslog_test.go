@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatRFC5424(t *testing.T) {
+	e := event{
+		Kind:         "alert",
+		Type:         "reward_missed",
+		Severity:     "critical",
+		Orchestrator: "0xabc",
+		Round:        4242,
+		Message:      "❌ No [reward](https://arbiscan.io/tx/0x1) \"called\"\nyet]",
+		Label:        `net "a"`,
+		Timestamp:    1700000000,
+	}
+	got := formatRFC5424(syslogConfig{AppName: "watcher"}, e, 3*8+2)
+	want := regexp.MustCompile(`^<26>1 2023-11-14T22:13:20Z \S+ watcher \d+ reward_missed ` +
+		regexp.QuoteMeta(`[reward-watcher@32473 kind="alert" severity="critical" orchestrator="0xabc" round="4242" label="net \"a\""] `+
+			`❌ No reward (https://arbiscan.io/tx/0x1) "called" yet]`) + `$`)
+	if !want.MatchString(got) {
+		t.Errorf("formatRFC5424() = %q", got)
+	}
+}
+
+func TestSyslogConn(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		listen   func() (addr string, read func() (string, error), closer func())
+		wantPri  string
+		severity string
+	}{
+		{
+			scheme: "udp",
+			listen: func() (string, func() (string, error), func()) {
+				pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				read := func() (string, error) {
+					buf := make([]byte, 4096)
+					pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+					n, _, err := pc.ReadFrom(buf)
+					return string(buf[:n]), err
+				}
+				return pc.LocalAddr().String(), read, func() { pc.Close() }
+			},
+			severity: "warning",
+			wantPri:  "<28>1 ",
+		},
+		{
+			scheme: "tcp",
+			listen: func() (string, func() (string, error), func()) {
+				l, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				conns := make(chan *bufio.Reader, 1)
+				go func() {
+					if c, err := l.Accept(); err == nil {
+						c.SetReadDeadline(time.Now().Add(5 * time.Second))
+						conns <- bufio.NewReader(c)
+					}
+				}()
+				var r *bufio.Reader
+				// Messages are framed by octet counting: "<length> <message>".
+				read := func() (string, error) {
+					if r == nil {
+						r = <-conns
+					}
+					length, err := r.ReadString(' ')
+					if err != nil {
+						return "", err
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(length))
+					if err != nil {
+						return "", fmt.Errorf("invalid frame length %q", length)
+					}
+					buf := make([]byte, n)
+					_, err = io.ReadFull(r, buf)
+					return string(buf), err
+				}
+				return l.Addr().String(), read, func() { l.Close() }
+			},
+			severity: "critical",
+			wantPri:  "<26>1 ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			addr, read, closer := tt.listen()
+			defer closer()
+			c, err := dialSyslog(syslogConfig{Address: tt.scheme + "://" + addr})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			// Both events go over the same connection.
+			for _, kind := range []string{"reward_missed", "reward"} {
+				if err := c.publish(event{Kind: "alert", Type: kind, Severity: tt.severity, Timestamp: 1700000000}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, kind := range []string{"reward_missed", "reward"} {
+				msg, err := read()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasPrefix(msg, tt.wantPri) || !strings.Contains(msg, " reward-watcher ") || !strings.HasSuffix(msg, " "+kind) {
+					t.Errorf("got %q, want a %s%s message from reward-watcher", msg, tt.wantPri, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestSyslogConnUnknownFacility(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	c, err := dialSyslog(syslogConfig{Address: "udp://" + pc.LocalAddr().String(), Facility: "local9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.publish(event{Type: "reward"}); err == nil {
+		t.Error("publish with an unknown facility succeeded")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// alertTarget is one destination of a channel that accepts several, such as a Telegram chat or a
+// Discord webhook, with its own minimum severity.
+type alertTarget struct {
+	Value       string
+	MinSeverity string
+}
+
+// parseAlertTargets parses a comma-separated target list. A target may be prefixed with a minimum
+// severity, e.g. "-1001234,critical:98765" sends only critical alerts to the second chat.
+func parseAlertTargets(raw string) []alertTarget {
+	var targets []alertTarget
+	for _, value := range splitCSV(raw) {
+		target := alertTarget{Value: value}
+		if severity, rest, ok := strings.Cut(value, ":"); ok {
+			if _, known := severityRanks[strings.ToLower(severity)]; known {
+				target = alertTarget{Value: strings.TrimSpace(rest), MinSeverity: strings.ToLower(severity)}
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// accepts reports whether the target receives an alert of the given severity.
+func (t alertTarget) accepts(severity string) bool {
+	return t.MinSeverity == "" || severityAtLeast(severity, t.MinSeverity)
+}
+
+// deliverTargets sends an alert to every target that accepts its severity and reports the
+// targets that failed.
+func deliverTargets(targets []alertTarget, severity string, send func(target string) error) error {
+	var failed []string
+	for i, t := range targets {
+		if !t.accepts(severity) {
+			continue
+		}
+		if err := send(t.Value); err != nil {
+			failed = append(failed, fmt.Sprintf("target %d: %v", i+1, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// discordWebhookID returns the ID of a Discord webhook, which identifies it without its token.
+// URLs in another format are identified by a hash.
+func discordWebhookID(webhookURL string) string {
+	if u, err := url.Parse(webhookURL); err == nil {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i, s := range segments {
+			if s == "webhooks" && i+1 < len(segments) {
+				return segments[i+1]
+			}
+		}
+	}
+	sum := sha256.Sum256([]byte(webhookURL))
+	return hex.EncodeToString(sum[:8])
+}
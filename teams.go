@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// teamsColors maps alert severities to Adaptive Card text colors.
+var teamsColors = map[string]string{
+	"critical": "Attention",
+	"warning":  "Warning",
+	"info":     "Good",
+}
+
+// sendTeamsAlert posts an alert to a Microsoft Teams incoming webhook as an Adaptive Card.
+func sendTeamsAlert(webhookURL, message, severity string, brand branding) error {
+	title := brand.Title
+	if title == "" {
+		title = defaultDiscordTitle
+	}
+	body := []map[string]interface{}{
+		{
+			"type":   "TextBlock",
+			"text":   title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"color":  teamsColors[severity],
+			"wrap":   true,
+		},
+		{
+			"type": "TextBlock",
+			// Adaptive Card markdown needs a blank line for a line break.
+			"text": strings.ReplaceAll(message, "\n", "\n\n"),
+			"wrap": true,
+		},
+	}
+	if brand.Footer != "" {
+		body = append(body, map[string]interface{}{
+			"type":     "TextBlock",
+			"text":     brand.Footer,
+			"size":     "Small",
+			"isSubtle": true,
+			"wrap":     true,
+		})
+	}
+	payload := map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]interface{}{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+				"msteams": map[string]string{"width": "Full"},
+			},
+		}},
+	}
+	if err := postJSON(webhookURL, payload); err != nil {
+		return fmt.Errorf("teams webhook: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// telegramPollTimeout is how long a getUpdates long poll waits for new messages.
+const telegramPollTimeout = 50 * time.Second
+
+// telegramPollClient outlives the long poll, unlike alertHTTPClient.
+var telegramPollClient = &http.Client{Timeout: telegramPollTimeout + 10*time.Second}
+
+// telegramCommands are the commands shown in the Telegram command menu.
+var telegramCommands = []map[string]string{
+	{"command": "status", "description": "Whether the watched orchestrators called reward this round"},
+	{"command": "round", "description": "The current round and when it started"},
+	{"command": "lastreward", "description": "The last reward call of every orchestrator"},
+	{"command": "mute", "description": "Silence all alerts, e.g. /mute 6h"},
+	{"command": "unmute", "description": "Resume sending alerts"},
+}
+
+// telegramBots groups the watchers by the Telegram bot token of their notifier, so every bot
+// answers for the networks it sends alerts for.
+func telegramBots(watchers []*watcher) map[string][]*watcher {
+	bots := make(map[string][]*watcher)
+	for _, w := range watchers {
+		if token := w.net.Notifier.TelegramBotToken; token != "" && w.net.Notifier.TelegramChatID != "" {
+			bots[token] = append(bots[token], w)
+		}
+	}
+	return bots
+}
+
+// runTelegramBot answers bot commands sent in the alert chats of the watchers, using long polling.
+// Messages from other chats are ignored, as anyone can message a bot.
+func runTelegramBot(token string, watchers []*watcher, status *statusBoard) {
+	chats := make(map[string]bool)
+	for _, w := range watchers {
+		for _, t := range parseAlertTargets(w.net.Notifier.TelegramChatID) {
+			chats[t.Value] = true
+		}
+	}
+	api := fmt.Sprintf("https://api.telegram.org/bot%s/", token)
+	if err := postJSON(api+"setMyCommands", map[string]interface{}{"commands": telegramCommands}); err != nil {
+		log.Printf("Telegram bot: failed to register commands: %v", err)
+	}
+
+	var offset int64
+	for {
+		updates, err := telegramUpdates(api, offset)
+		if err != nil {
+			log.Printf("Telegram bot: failed to poll updates: %v", err)
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || !chats[strconv.FormatInt(u.Message.Chat.ID, 10)] {
+				continue
+			}
+			text := strings.TrimSpace(u.Message.Text)
+			if !strings.HasPrefix(text, "/") {
+				continue
+			}
+			command, arg, _ := strings.Cut(text[1:], " ")
+			// Commands in groups may be addressed to a bot as /status@name_bot.
+			command, _, _ = strings.Cut(command, "@")
+			user := "a Telegram channel post"
+			if from := u.Message.From; from != nil {
+				user = fmt.Sprintf("Telegram user %s (%d)", from.Username, from.ID)
+			}
+			reply := runBotCommand(watchers, status, user, strings.ToLower(command), arg)
+			chatID := strconv.FormatInt(u.Message.Chat.ID, 10)
+			if _, err := sendTelegramAlert(token, chatID, u.Message.ThreadID, reply, u.Message.MessageID, false); err != nil {
+				log.Printf("Telegram bot: failed to answer /%s: %v", command, err)
+			}
+		}
+	}
+}
+
+// telegramUpdate is an incoming Telegram update; only messages are requested.
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		MessageID int    `json:"message_id"`
+		ThreadID  int    `json:"message_thread_id"`
+		Text      string `json:"text"`
+		From      *struct {
+			ID       int64  `json:"id"`
+			Username string `json:"username"`
+		} `json:"from"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// telegramUpdates long-polls the bot's updates starting at offset.
+func telegramUpdates(api string, offset int64) ([]telegramUpdate, error) {
+	q := url.Values{}
+	q.Set("offset", strconv.FormatInt(offset, 10))
+	q.Set("timeout", strconv.Itoa(int(telegramPollTimeout.Seconds())))
+	q.Set("allowed_updates", `["message"]`)
+	resp, err := telegramPollClient.Get(api + "getUpdates?" + q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		// 409 means a webhook or another process is consuming the bot's updates.
+		return nil, fmt.Errorf("telegram API returned HTTP %d", resp.StatusCode)
+	}
+	var result struct {
+		Result []telegramUpdate `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// alertTemplates holds user templates for alert texts, keyed by alert type. The "default"
+// template applies to alert types without their own template.
+type alertTemplates map[string]*template.Template
+
+// templateData is the data alert templates are executed with.
+type templateData struct {
+	Type     string
+	Severity string
+	// Message is the built-in alert text.
+	Message         string
+	Orchestrator    string
+	OrchestratorURL string
+	Round           uint64
+	Block           uint64
+	TxHash          string
+	TxURL           string
+	// Elapsed is the time since the current round started, rounded to the minute, and
+	// RoundStart its start time.
+	Elapsed    time.Duration
+	RoundStart time.Time
+	// Time is when the alert is sent.
+	Time  time.Time
+	Label string
+	// Lang is the language selected with --lang.
+	Lang string
+}
+
+var templateFuncs = template.FuncMap{
+	// duration and date format durations and times in the selected language.
+	"duration": formatDuration,
+	"date":     formatDate,
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	// short abbreviates an address or hash to 0x1234…abcd.
+	"short": func(s string) string {
+		if len(s) <= 12 {
+			return s
+		}
+		return s[:6] + "…" + s[len(s)-4:]
+	},
+}
+
+// loadTemplates parses the alert templates in dir: one <type>.tmpl file per alert type, e.g.
+// reward_missed.tmpl, and optionally default.tmpl. A <type>.<lang>.tmpl file, e.g.
+// reward_missed.de.tmpl, takes precedence when that language is selected.
+func loadTemplates(dir string) (alertTemplates, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.tmpl files in %s", dir)
+	}
+	out := make(alertTemplates)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		localized := false
+		if base, lang, ok := strings.Cut(kind, "."); ok {
+			if lang != language {
+				continue
+			}
+			kind, localized = base, true
+		}
+		if _, ok := out[kind]; ok && !localized {
+			continue
+		}
+		t, err := template.New(kind).Funcs(templateFuncs).Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %v", path, err)
+		}
+		out[kind] = t
+	}
+	return out, nil
+}
+
+// render returns the alert text produced by the template of the alert type, or the built-in
+// text if there is no template or it fails.
+func (t alertTemplates) render(a alert, label string) string {
+	tmpl := t[a.Type]
+	if tmpl == nil {
+		tmpl = t["default"]
+	}
+	if tmpl == nil {
+		return a.Message
+	}
+	data := templateData{
+		Type:       a.Type,
+		Severity:   a.severity(),
+		Message:    a.Message,
+		Round:      a.Round,
+		Block:      a.Block,
+		TxHash:     a.Tx,
+		Elapsed:    a.Elapsed,
+		RoundStart: a.RoundStart,
+		Time:       time.Now(),
+		Label:      label,
+		Lang:       language,
+	}
+	if a.Orchestrator != (common.Address{}) {
+		data.Orchestrator = strings.ToLower(a.Orchestrator.Hex())
+		data.OrchestratorURL = fmt.Sprintf("https://explorer.livepeer.org/accounts/%s/delegating", data.Orchestrator)
+	}
+	if a.Tx != "" {
+		data.TxURL = "https://arbiscan.io/tx/" + a.Tx
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		log.Printf("alert template %s failed, using the built-in text: %v", tmpl.Name(), err)
+		return a.Message
+	}
+	return strings.TrimSpace(b.String())
+}
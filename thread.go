@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// threadedRounds is the number of most recent rounds whose thread roots are remembered.
+const threadedRounds = 3
+
+// alertThreads remembers the first message posted for each round per channel target, so
+// follow-up alerts of that round can be posted as replies. A nil *alertThreads disables threading.
+type alertThreads struct {
+	mu sync.Mutex
+	// telegramRoots are keyed by chat ID, discordRoots by webhook ID, and then by round.
+	telegramRoots map[string]map[uint64]int
+	discordRoots  map[string]map[uint64]string
+	latestRound   uint64
+}
+
+// newAlertThreads creates an empty thread store.
+func newAlertThreads() *alertThreads {
+	return &alertThreads{telegramRoots: make(map[string]map[uint64]int), discordRoots: make(map[string]map[uint64]string)}
+}
+
+// pruneRounds forgets the roots of rounds older than the last threadedRounds rounds.
+func pruneRounds[V any](roots map[uint64]V, round uint64) {
+	for r := range roots {
+		if r+threadedRounds <= round {
+			delete(roots, r)
+		}
+	}
+}
+
+// telegramRoot returns the Telegram message ID of the round's first message in a chat (0 = none).
+func (t *alertThreads) telegramRoot(chat string, round uint64) int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.telegramRoots[chat][round]
+}
+
+// setTelegramRoot records the Telegram message ID of the round's first message in a chat.
+func (t *alertThreads) setTelegramRoot(chat string, round uint64, messageID int) {
+	if t == nil || messageID == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.telegramRoots[chat] == nil {
+		t.telegramRoots[chat] = make(map[uint64]int)
+	}
+	t.telegramRoots[chat][round] = messageID
+	pruneRounds(t.telegramRoots[chat], round)
+}
+
+// discordThread returns the Discord thread of a webhook to post a round's alert in: the round's
+// existing thread, or a new "Round N" thread. Alerts not tied to a round go to the latest round's thread.
+func (t *alertThreads) discordThread(webhook string, round uint64) discordThread {
+	if t == nil {
+		return discordThread{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	roots := t.discordRoots[discordWebhookID(webhook)]
+	if round == 0 {
+		if id, ok := roots[t.latestRound]; ok {
+			return discordThread{ID: id}
+		}
+		return discordThread{Name: "Livepeer Reward Watcher"}
+	}
+	if id, ok := roots[round]; ok {
+		return discordThread{ID: id}
+	}
+	return discordThread{Name: fmt.Sprintf("Round %d", round)}
+}
+
+// discordThreadIDs returns the remembered Discord threads by webhook ID and round.
+func (t *alertThreads) discordThreadIDs() map[string]map[uint64]string {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]map[uint64]string, len(t.discordRoots))
+	for webhookID, roots := range t.discordRoots {
+		out[webhookID] = make(map[uint64]string, len(roots))
+		for r, id := range roots {
+			out[webhookID][r] = id
+		}
+	}
+	return out
+}
+
+// setDiscordThread records the Discord thread created for a round, by webhook ID.
+func (t *alertThreads) setDiscordThread(webhookID string, round uint64, threadID string) {
+	if t == nil || threadID == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.discordRoots[webhookID] == nil {
+		t.discordRoots[webhookID] = make(map[uint64]string)
+	}
+	t.discordRoots[webhookID][round] = threadID
+	if round > t.latestRound {
+		t.latestRound = round
+	}
+	pruneRounds(t.discordRoots[webhookID], round)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// loadClientTLS builds a TLS config presenting an optional client certificate and trusting an
+// optional CA bundle in addition to the system roots. It returns nil when nothing is configured.
+func loadClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for mutual TLS")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// tlsHTTPClient returns an HTTP client that uses the given TLS config.
+func tlsHTTPClient(cfg *tls.Config, base *http.Client) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{Timeout: base.Timeout, Transport: transport}
+}
+
+// rpcTLSOptions returns RPC client options that use the given TLS config for HTTP and WebSocket endpoints.
+func rpcTLSOptions(cfg *tls.Config) []rpc.ClientOption {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = cfg
+	return []rpc.ClientOption{
+		rpc.WithHTTPClient(tlsHTTPClient(cfg, &http.Client{})),
+		rpc.WithWebsocketDialer(dialer),
+	}
+}
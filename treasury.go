@@ -0,0 +1,35 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// treasuryCut returns the LPT amount sent to the protocol treasury for the given orchestrator
+// in a reward transaction, decoded from the BondingManager TreasuryReward events in its receipt.
+func treasuryCut(bondingABI abi.ABI, bondingManager common.Address, receipt *types.Receipt, orch common.Address) *big.Int {
+	total := new(big.Int)
+	event, ok := bondingABI.Events["TreasuryReward"]
+	if !ok || receipt == nil {
+		return total
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != bondingManager || len(l.Topics) < 2 || l.Topics[0] != event.ID {
+			continue
+		}
+		if common.BytesToAddress(l.Topics[1].Bytes()) != orch {
+			continue
+		}
+		values, err := bondingABI.Unpack("TreasuryReward", l.Data)
+		if err != nil || len(values) == 0 {
+			continue
+		}
+		if amount, ok := values[0].(*big.Int); ok {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
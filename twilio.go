@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const twilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+// twilioConfig configures the Twilio SMS alert channel.
+type twilioConfig struct {
+	AccountSID string   `json:"accountSid"`
+	AuthToken  string   `json:"authToken"`
+	From       string   `json:"from"`
+	To         []string `json:"to"`
+	// VoiceTo lists the numbers called for escalations (default: To).
+	VoiceTo []string `json:"voiceTo"`
+	// MinSeverity is the lowest severity sent by SMS: critical, warning or info (default: critical).
+	MinSeverity string `json:"minSeverity"`
+}
+
+func (c twilioConfig) complete() bool {
+	return c.AccountSID != "" && c.AuthToken != "" && c.From != "" && len(c.To) > 0
+}
+
+// twilioRequest posts a form to the Twilio REST API of the account.
+func twilioRequest(cfg twilioConfig, resource string, form url.Values) error {
+	endpoint := fmt.Sprintf("%s/Accounts/%s/%s", twilioAPIURL, url.PathEscape(cfg.AccountSID), resource)
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(cfg.AccountSID, cfg.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio API returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendTwilioSMS texts an alert to every configured number. Links are reduced to their text to
+// keep messages short.
+func sendTwilioSMS(cfg twilioConfig, message string) error {
+	body := markdownLinkRe.ReplaceAllString(message, "$1")
+	var failed []string
+	for _, to := range cfg.To {
+		form := url.Values{"From": {cfg.From}, "To": {to}, "Body": {body}}
+		if err := twilioRequest(cfg, "Messages.json", form); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", to, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("SMS failed for %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// spokenAddress renders the end of an address character by character for text-to-speech.
+func spokenAddress(o *orchestrator) string {
+	hex := strings.ToLower(o.address.Hex())
+	return "ending in " + strings.Join(strings.Split(hex[len(hex)-4:], ""), " ")
+}
+
+// callTwilioVoice places a phone call to every voice number that reads out the message.
+func callTwilioVoice(cfg twilioConfig, speech string) error {
+	to := cfg.VoiceTo
+	if len(to) == 0 {
+		to = cfg.To
+	}
+	twiml := fmt.Sprintf(`<Response><Say loop="2">%s</Say></Response>`, html.EscapeString(speech))
+	var failed []string
+	for _, number := range to {
+		form := url.Values{"From": {cfg.From}, "To": {number}, "Twiml": {twiml}}
+		if err := twilioRequest(cfg, "Calls.json", form); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", number, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("voice call failed for %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// call escalates an alert to a phone call when Twilio is configured.
+func (n *notifier) call(speech string) error {
+	if !n.Twilio.complete() {
+		return nil
+	}
+	if n.Label != "" {
+		speech = n.Label + ". " + speech
+	}
+	err := callTwilioVoice(n.Twilio, redact(speech))
+	if err != nil {
+		log.Printf("Voice call error: %v", err)
+	}
+	recordChannelResult("voice", err)
+	return err
+}
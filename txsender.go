@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// txMineTimeout is how long a submitted transaction is waited on before it is reported as unconfirmed.
+const txMineTimeout = 10 * time.Minute
+
+// txSigner is the account the watcher submits its own transactions from.
+type txSigner struct {
+	address common.Address
+	key     *ecdsa.PrivateKey
+}
+
+// loadTxSigner loads the signing key from an encrypted keystore file, or from a hex private key.
+// It returns nil when neither is configured.
+func loadTxSigner(keystoreFile, password, privateKey string) (*txSigner, error) {
+	if keystoreFile != "" {
+		data, err := os.ReadFile(keystoreFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %v", err)
+		}
+		key, err := keystore.DecryptKey(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock keystore: %v", err)
+		}
+		return &txSigner{address: key.Address, key: key.PrivateKey}, nil
+	}
+	if privateKey == "" {
+		return nil, nil
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return &txSigner{address: crypto.PubkeyToAddress(key.PublicKey), key: key}, nil
+}
+
+// submitTx signs and sends a call to a contract method without arguments from the watcher's
+// account. The call is simulated first, and nothing is sent while the gas price exceeds
+// --tx-max-gas-price, the gas needed exceeds --tx-gas-limit or earlier transactions of the
+// account are still pending, since a new one would only queue behind them. onMined runs on the
+// monitoring loop once the transaction is mined, or with an error if it wasn't in time.
+func (w *watcher) submitTx(contract common.Address, contractABI abi.ABI, method string, onMined func(receipt *types.Receipt, err error)) (common.Hash, error) {
+	signer := w.opts.txSigner
+	data, err := contractABI.Pack(method)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	gasPrice, err := w.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch gas price: %v", err)
+	}
+	if limit := w.opts.txMaxGasPrice; limit != nil && gasPrice.Cmp(limit) > 0 {
+		return common.Hash{}, fmt.Errorf("gas price of %s gwei exceeds --tx-max-gas-price of %s gwei", formatUnits(gasPrice, 9, 4), formatUnits(limit, 9, 4))
+	}
+	msg := ethereum.CallMsg{From: signer.address, To: &contract, GasPrice: gasPrice, Data: data}
+	if _, err := w.client.CallContract(ctx, msg, nil); err != nil {
+		return common.Hash{}, fmt.Errorf("simulation reverts: %s", revertReason(err))
+	}
+	gas, err := w.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("gas estimation failed: %v", err)
+	}
+	if limit := w.opts.txGasLimit; limit > 0 && gas > limit {
+		return common.Hash{}, fmt.Errorf("estimated gas of %d exceeds --tx-gas-limit of %d", gas, limit)
+	}
+	nonce, err := w.client.PendingNonceAt(ctx, signer.address)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch nonce: %v", err)
+	}
+	confirmed, err := w.client.NonceAt(ctx, signer.address, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch nonce: %v", err)
+	}
+	if nonce > confirmed {
+		return common.Hash{}, fmt.Errorf("%s has %d pending transaction(s)", signer.address.Hex(), nonce-confirmed)
+	}
+	chainID, err := w.client.ChainID(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch chain ID: %v", err)
+	}
+	// Arbitrum gas estimates include the L1 fee and can move between blocks, so leave headroom.
+	gas = gas * 12 / 10
+	if limit := w.opts.txGasLimit; limit > 0 && gas > limit {
+		gas = limit
+	}
+	tx := types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: gasPrice, Gas: gas, To: &contract, Data: data})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), signer.key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := w.client.SendTransaction(ctx, signed); err != nil {
+		return common.Hash{}, err
+	}
+
+	client, tasks, done := w.client, w.tasks, w.done
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), txMineTimeout)
+		defer cancel()
+		receipt, err := bind.WaitMined(ctx, client, signed)
+		select {
+		case tasks <- func() { onMined(receipt, err) }:
+		case <-done:
+		}
+	}()
+	return signed.Hash(), nil
+}
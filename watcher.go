@@ -0,0 +1,787 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// contractABIs holds the parsed ABIs of the watched Livepeer contracts.
+type contractABIs struct {
+	BondingManager  abi.ABI
+	RoundsManager   abi.ABI
+	TicketBroker    abi.ABI
+	ServiceRegistry abi.ABI
+}
+
+// loadContractABIs loads all contract ABIs downloaded at build time.
+func loadContractABIs() (*contractABIs, error) {
+	var abis contractABIs
+	var err error
+	if abis.BondingManager, err = loadABI("BondingManager"); err != nil {
+		return nil, err
+	}
+	if abis.RoundsManager, err = loadABI("RoundsManager"); err != nil {
+		return nil, err
+	}
+	if abis.TicketBroker, err = loadABI("TicketBroker"); err != nil {
+		return nil, err
+	}
+	if abis.ServiceRegistry, err = loadABI("ServiceRegistry"); err != nil {
+		return nil, err
+	}
+	return &abis, nil
+}
+
+// subscriptionError is an error reported by one of the watcher's log subscriptions.
+type subscriptionError struct {
+	name string
+	err  error
+}
+
+// services holds the process-wide components shared by all watchers.
+type services struct {
+	abis     *contractABIs
+	exporter *exportWriter
+	status   *statusBoard
+	state    *stateStore
+}
+
+// watcher monitors the reward calls of one or more orchestrators on a single network.
+type watcher struct {
+	opts     *options
+	net      network
+	abis     *contractABIs
+	exporter *exportWriter
+	status   *statusBoard
+	state    *stateStore
+	log      *log.Logger
+
+	// Connection state.
+	client       *ethclient.Client
+	rpcURL       string
+	connectedRPC string
+	// reconnectRequested makes the monitoring loop reconnect after the current task.
+	reconnectRequested bool
+	rpcListUpdated     time.Time
+	subs               []ethereum.Subscription
+	subErr             chan subscriptionError
+	tasks              chan func()
+	done               chan struct{}
+	// control runs requests from other goroutines (e.g. state dumps) on the monitoring loop.
+	control chan func()
+
+	// Round state.
+	currentRound  uint64
+	roundStart    time.Time
+	orchestrators []*orchestrator
+	// roundBlocks caches the start block and length of the current round for --delay-progress.
+	roundBlocks roundBlocks
+	roundInit   roundInitState
+
+	abiImplementations map[string]common.Address
+	claimLagging       map[common.Address]bool
+	callerTxs          map[common.Address]callerTxCursor
+	// unconfirmed are live events waiting for --confirmations blocks.
+	unconfirmed       []unconfirmedLog
+	txpoolUnsupported bool
+	wrongChainRPCs    map[string]bool
+	keepalive         keepaliveState
+	// returningToPrimary is set while reconnecting to the primary RPC after a failover.
+	returningToPrimary         bool
+	rpcHealth                  map[string]*rpcHealth
+	quorumClients              map[string]*ethclient.Client
+	nodeVersion                nodeVersionState
+	headLag                    headLagState
+	networkRewardStall         *stallDetector
+	roundStall                 *stallDetector
+	sentInitialMonitoringAlert bool
+
+	// Catch-up state: caughtUpTo is the last block replayed from historical logs, catchingUp is
+	// set while replaying and silent suppresses alerts for events that happened before startup.
+	caughtUpTo uint64
+	catchingUp bool
+	silent     bool
+
+	// Status reporting.
+	lastEventTime  time.Time
+	lastAlertTime  time.Time
+	lastAlertError string
+}
+
+// newWatcher creates a watcher for the given network.
+func newWatcher(opts *options, net network, svc *services) *watcher {
+	prefix := ""
+	if net.Notifier.Label != "" {
+		prefix = fmt.Sprintf("[%s] ", net.Notifier.Label)
+	}
+	orchestrators := make([]*orchestrator, 0, len(net.Orchestrators))
+	for _, cfg := range net.Orchestrators {
+		orchestrators = append(orchestrators, newOrchestrator(cfg))
+	}
+	return &watcher{
+		opts:               opts,
+		net:                net,
+		abis:               svc.abis,
+		exporter:           svc.exporter,
+		status:             svc.status,
+		state:              svc.state,
+		control:            make(chan func()),
+		abiImplementations: make(map[string]common.Address),
+		claimLagging:       make(map[common.Address]bool),
+		callerTxs:          make(map[common.Address]callerTxCursor),
+		wrongChainRPCs:     make(map[string]bool),
+		quorumClients:      make(map[string]*ethclient.Client),
+		rpcHealth:          make(map[string]*rpcHealth),
+		log:                log.New(logOutput, prefix, log.LstdFlags),
+		orchestrators:      orchestrators,
+		networkRewardStall: newStallDetector(opts.networkStallTimeout),
+		roundStall:         newStallDetector(opts.roundStallTimeout),
+	}
+}
+
+// send delivers an alert through the watcher's alert channels, unless alerts are silenced.
+func (w *watcher) send(a alert) {
+	if w.silent {
+		return
+	}
+	if !w.roundStart.IsZero() {
+		a.Elapsed = time.Since(w.roundStart).Round(time.Minute)
+		a.RoundStart = w.roundStart
+	}
+	w.recordAlert(w.net.Notifier.sendAlert(a))
+}
+
+// alert sends a message of the given event type through the watcher's alert channels.
+func (w *watcher) alert(kind, message string, color int) {
+	w.send(alert{Type: kind, Message: message, Color: color})
+}
+
+// recordAlert remembers the outcome of the last alert for status reporting.
+func (w *watcher) recordAlert(err error) {
+	w.lastAlertTime = time.Now()
+	w.lastAlertError = ""
+	if err != nil {
+		w.lastAlertError = err.Error()
+	}
+}
+
+// roundAlert sends a message about the current round, threaded with the round's other alerts
+// on channels that support it.
+func (w *watcher) roundAlert(kind, message string, color int) {
+	w.send(alert{Type: kind, Message: message, Color: color, Round: w.currentRound})
+}
+
+// orchestratorAlert sends a message about an orchestrator in the current round.
+func (w *watcher) orchestratorAlert(o *orchestrator, kind, message string, color int) {
+	w.send(alert{Type: kind, Message: message, Color: color, Round: w.currentRound, Orchestrator: o.address})
+}
+
+// incidentAlert sends an alert that opens an incident, which stays open on paging channels
+// until resolveIncident is called with the same incident.
+func (w *watcher) incidentAlert(inc incident, a alert) {
+	if w.silent {
+		return
+	}
+	a.Incident = inc.Key
+	w.send(a)
+	if w.net.Notifier.routed("pagerduty", a.Type) && a.to("pagerduty") {
+		w.net.Notifier.triggerIncident(inc)
+	}
+}
+
+// subscribe opens a log subscription and tracks it so it can be torn down on disconnect.
+// Endpoints without subscription support are polled instead.
+func (w *watcher) subscribe(name string, query ethereum.FilterQuery) (chan types.Log, error) {
+	ch := make(chan types.Log)
+	sub, err := w.client.SubscribeFilterLogs(context.Background(), query, ch)
+	if subscriptionsUnsupported(err) {
+		w.log.Printf("RPC does not support subscriptions, polling %s events every %s", name, w.opts.pollInterval)
+		sub, err = w.pollLogs(query, ch)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s subscription failed: %v", name, err)
+	}
+	w.track(name, sub)
+	return ch, nil
+}
+
+// subscribeHeads opens a new block header subscription, or polls endpoints without
+// subscription support.
+func (w *watcher) subscribeHeads() (chan *types.Header, error) {
+	ch := make(chan *types.Header)
+	sub, err := w.client.SubscribeNewHead(context.Background(), ch)
+	if subscriptionsUnsupported(err) {
+		sub, err = w.pollHeads(ch), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewHead subscription failed: %v", err)
+	}
+	w.track("NewHead", sub)
+	return ch, nil
+}
+
+// track registers a subscription for teardown on disconnect and forwards its error to the
+// monitoring loop.
+func (w *watcher) track(name string, sub ethereum.Subscription) {
+	w.subs = append(w.subs, sub)
+	subErr, done := w.subErr, w.done
+	go func() {
+		err, ok := <-sub.Err()
+		if !ok {
+			return
+		}
+		select {
+		case subErr <- subscriptionError{name: name, err: err}:
+		case <-done:
+		}
+	}()
+}
+
+// schedule runs a check on the monitoring loop right away and then every interval until
+// the connection is torn down. A zero interval disables the check.
+func (w *watcher) schedule(interval time.Duration, check func()) {
+	if interval <= 0 {
+		return
+	}
+	tasks, done := w.tasks, w.done
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case tasks <- check:
+			case <-done:
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// after runs a check on the monitoring loop once d has passed, unless the connection is torn
+// down first.
+func (w *watcher) after(d time.Duration, check func()) {
+	tasks, done := w.tasks, w.done
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-done:
+			return
+		}
+		select {
+		case tasks <- check:
+		case <-done:
+		}
+	}()
+}
+
+// disconnect unsubscribes all subscriptions and closes the RPC client.
+func (w *watcher) disconnect() {
+	close(w.done)
+	// Let the catch-up after reconnecting replay the events still waiting for confirmations.
+	for _, u := range w.unconfirmed {
+		if u.log.BlockNumber <= w.headLag.lastProcessed {
+			w.headLag.lastProcessed = u.log.BlockNumber - 1
+		}
+	}
+	w.unconfirmed = nil
+	w.closeQuorumClients()
+	for _, sub := range w.subs {
+		sub.Unsubscribe()
+	}
+	w.subs = nil
+	w.client.Close()
+}
+
+// refuseRPC alerts once per endpoint that an RPC serves another chain than the network's, e.g.
+// an Ethereum mainnet or testnet endpoint passed by mistake.
+func (w *watcher) refuseRPC(url string, chainID *big.Int) {
+	w.log.Printf("refusing RPC %s: chain ID %s, expected %d", maskRPCURL(url), chainID, w.net.ChainID)
+	if w.wrongChainRPCs[url] {
+		return
+	}
+	w.wrongChainRPCs[url] = true
+	msg := fmt.Sprintf(
+		tr("❌ RPC %s serves chain ID %s instead of %d, so it is not used. Check that it is an endpoint of %s."),
+		maskRPCURL(url), chainID, w.net.ChainID, w.net.Name)
+	w.alert("rpc_wrong_chain", msg, 0xFF0000)
+}
+
+// run connects to the network and monitors it forever, failing over between RPCs.
+func (w *watcher) run() {
+	w.restoreState()
+	retryStartTime := time.Now()
+	connected := false
+	for {
+		// Stop if max retry time exceeded.
+		if w.opts.maxRetryTime > 0 && time.Since(retryStartTime) > w.opts.maxRetryTime {
+			fatalMsg := fmt.Sprintf(tr("❌ Failed to connect to any RPC after %s, giving up and shutting down reward watcher!"), formatDuration(w.opts.maxRetryTime))
+			w.alert("rpc_failed", fatalMsg, 0xFF0000)
+			flushEventSinks(10 * time.Second)
+			w.log.Fatalf("%s", fatalMsg)
+		}
+
+		// Refresh a remote RPC list that went stale while disconnected.
+		if w.net.RPCListURL != "" && time.Since(w.rpcListUpdated) > w.opts.rpcListRefresh {
+			w.refreshRPCList()
+		}
+
+		// Try to connect to an RPC endpoint.
+		client, usedRPC, err := connectToRPC(w.rankedRPCs(), w.net.ChainID, w.opts.wssUpgrade, w.refuseRPC)
+		if err != nil {
+			w.log.Printf("RPC connection failed: %v", err)
+			time.Sleep(30 * time.Second)
+			continue
+		}
+		w.log.Printf("Connected to %s", maskRPCURL(usedRPC))
+		w.publishLifecycle("rpc_connected", "info", fmt.Sprintf("Connected to %s", maskRPCURL(usedRPC)))
+		previousRPC := w.rpcURL
+		w.client = client
+		w.rpcURL = usedRPC
+		w.connectedRPC = maskRPCURL(usedRPC)
+		w.subErr = make(chan subscriptionError)
+		w.tasks = make(chan func())
+		w.done = make(chan struct{})
+
+		// Subscribe to events.
+		queries := w.eventQueries()
+		rewardCh, err := w.subscribe("Reward", queries.reward)
+		var roundCh, ticketCh, deactivationCh, contractInfoCh, networkRewardCh chan types.Log
+		if err == nil {
+			roundCh, err = w.subscribe("NewRound", queries.newRound)
+		}
+		if err == nil {
+			ticketCh, err = w.subscribe("WinningTicketRedeemed", queries.ticket)
+		}
+		if err == nil {
+			deactivationCh, err = w.subscribe("TranscoderDeactivated", queries.deactivation)
+		}
+		// Watch the Controller for contract upgrades to refresh ABIs.
+		if err == nil && w.opts.explorerAPIKey != "" {
+			contractInfoCh, err = w.subscribe("SetContractInfo", ethereum.FilterQuery{
+				Addresses: []common.Address{w.net.Contracts.Controller},
+				Topics:    [][]common.Hash{{controllerABI.Events["SetContractInfo"].ID}},
+			})
+		}
+		// Watch Reward events across the whole network for stall detection.
+		if err == nil && w.opts.networkStallTimeout > 0 {
+			networkRewardCh, err = w.subscribe("Network Reward", ethereum.FilterQuery{
+				Addresses: []common.Address{w.net.Contracts.BondingManager},
+				Topics:    [][]common.Hash{{w.abis.BondingManager.Events["Reward"].ID}},
+			})
+		}
+		var headCh chan *types.Header
+		if err == nil && (w.opts.headLagThreshold > 0 || w.opts.confirmations > 0 || w.opts.quorum > 1) {
+			headCh, err = w.subscribeHeads()
+		}
+		if err != nil {
+			w.log.Printf("%v", err)
+			w.disconnect()
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		// Replay events missed before the subscriptions were established.
+		w.catchUp()
+		w.syncCurrentRound()
+
+		// Round and Reward monitoring loop.
+		w.log.Println("Monitoring started...")
+		if !w.sentInitialMonitoringAlert {
+			links := make([]string, 0, len(w.orchestrators))
+			for _, o := range w.orchestrators {
+				links = append(links, o.link())
+			}
+			monitoringMsg := fmt.Sprintf(
+				tr("🟢 Livepeer Reward watcher monitoring orchestrator %s on %s."),
+				strings.Join(links, ", "), w.net.Name)
+			w.alert("monitoring_started", monitoringMsg, 0x00FF00)
+			w.sentInitialMonitoringAlert = true
+		} else if connected {
+			metrics.inc("reward_watcher_rpc_reconnects_total", "network", w.net.Name)
+			recoveryMsg := fmt.Sprintf(tr("✅ RPC connection restored to %s, resuming monitoring."), maskRPCURL(usedRPC))
+			if w.returningToPrimary && usedRPC == w.primaryRPC() {
+				w.reportPrimaryReturn(previousRPC, usedRPC)
+			} else if w.opts.enableRPCAlerts {
+				w.alert("rpc_reconnected", recoveryMsg, 0x00FF00)
+			}
+		}
+		w.returningToPrimary = false
+		connected = true
+		w.publishLifecycle("monitoring_started", "info", fmt.Sprintf("Monitoring %s from round %d", w.net.Name, w.currentRound))
+		w.refreshDeactivationRounds()
+		ticker := time.NewTicker(w.opts.checkInterval)
+		w.schedule(w.opts.serviceURICheckInterval, w.checkServiceURIs)
+		if w.opts.explorerAPIKey != "" {
+			w.schedule(w.opts.abiRefreshInterval, w.refreshABIs)
+		}
+		if w.net.RPCListURL != "" {
+			w.schedule(w.opts.rpcListRefresh, w.refreshRPCList)
+		}
+		if w.net.NodeStatusURL != "" {
+			w.schedule(w.opts.versionCheckInterval, w.checkNodeVersion)
+		}
+		if w.opts.headLagThreshold > 0 {
+			w.schedule(w.opts.headLagCheckInterval, w.checkHeadLag)
+		}
+		if w.opts.finalCall > 0 {
+			w.schedule(w.opts.checkInterval, w.checkFinalCall)
+		}
+		if w.opts.uninitializedRoundDelay > 0 || w.opts.autoInitializeRound > 0 {
+			w.schedule(roundInitCheckInterval, w.checkRoundInitialized)
+		}
+		if w.opts.autoReward > 0 {
+			w.schedule(autoRewardCheckInterval, w.checkAutoReward)
+		}
+		w.schedule(w.opts.revertScanInterval, w.checkRevertedTxs)
+		w.keepalive = keepaliveState{}
+		w.schedule(w.opts.keepaliveInterval, w.probeConnection)
+		w.schedule(w.opts.rpcHealthInterval, w.checkRPCHealth)
+		w.schedule(w.opts.primaryCheckInterval, w.checkPrimaryRPC)
+	monitorLoop:
+		for {
+			select {
+			case subErr := <-w.subErr:
+				w.log.Printf("%s subscription error: %v", subErr.name, subErr.err)
+				metrics.inc("reward_watcher_subscription_errors_total", "network", w.net.Name, "subscription", subErr.name)
+				if w.opts.enableRPCAlerts {
+					w.alert("subscription_error", fmt.Sprintf(tr("⚠️ %s subscription error: %v"), subErr.name, subErr.err), 0xFF0000)
+				}
+				break monitorLoop
+			case <-networkRewardCh:
+				if w.networkRewardStall.seen() {
+					recoveredMsg := tr("✅ Reward events are being observed on the network again.")
+					w.log.Println(recoveredMsg)
+					w.alert("network_reward_stall_resolved", recoveredMsg, 0x00FF00)
+				}
+			case vLog := <-rewardCh:
+				w.handleLog(vLog, w.handleReward)
+			case vLog := <-ticketCh:
+				w.handleLog(vLog, w.handleWinningTicket)
+			case vLog := <-deactivationCh:
+				w.handleLog(vLog, w.handleTranscoderDeactivated)
+			case vLog := <-contractInfoCh:
+				w.handleSetContractInfo(vLog)
+			case vLog := <-roundCh:
+				w.handleLog(vLog, w.handleNewRound)
+			case head := <-headCh:
+				// Heads arrive every block; skip the status update until something else happens.
+				w.headLag.processed(head.Number.Uint64())
+				if !w.confirmLogs(head.Number.Uint64()) {
+					continue
+				}
+			case <-ticker.C:
+				w.check()
+			case task := <-w.tasks:
+				task()
+			case req := <-w.control:
+				req()
+			}
+			w.publishStatus()
+			w.saveState()
+			if w.reconnectRequested {
+				w.reconnectRequested = false
+				break monitorLoop
+			}
+		}
+
+		// Cleanup state before reconnecting.
+		ticker.Stop()
+		w.disconnect()
+		w.publishLifecycle("rpc_disconnected", "warning", fmt.Sprintf("Disconnected from %s, reconnecting", w.connectedRPC))
+		w.rpcURL = ""
+		w.connectedRPC = ""
+		w.publishStatus()
+		time.Sleep(5 * time.Second) // Brief pause before trying to reconnect
+		retryStartTime = time.Now() // Start retry timer
+	}
+}
+
+// handleReward processes a Reward event of a watched orchestrator.
+func (w *watcher) handleReward(vLog types.Log) {
+	if len(vLog.Topics) < 2 {
+		return
+	}
+	o := w.orchestratorByTopic(vLog.Topics[1])
+	if o == nil {
+		return
+	}
+	o.rewardCalled = true
+	o.rewardTime = w.eventTime(vLog)
+	o.handledReward = &handledReward{tx: vLog.TxHash, index: vLog.Index, round: w.currentRound}
+	o.lastRewardRound, o.lastRewardTime, o.lastRewardTx = w.currentRound, o.rewardTime, vLog.TxHash.Hex()
+	if o.sentWarning {
+		// Also resolves incidents opened before a restart, so this runs while catching up too.
+		w.net.Notifier.resolveIncident(rewardIncident(o, w.currentRound, ""))
+	}
+	var minted *big.Int
+	if values, err := w.abis.BondingManager.Unpack("Reward", vLog.Data); err == nil && len(values) > 0 {
+		minted, _ = values[0].(*big.Int)
+	}
+	if minted != nil {
+		o.roundMinted.Add(o.roundMinted, minted)
+		o.handledReward.minted = minted
+	}
+	rewardEvent := w.logEvent(vLog, "Reward", o)
+	rewardEvent.Amount = formatUnits(minted, 18, 18)
+	w.publishEvent(rewardEvent)
+	txHash := vLog.TxHash.Hex()
+	receiptCtx, receiptCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	receipt, err := w.client.TransactionReceipt(receiptCtx, vLog.TxHash)
+	receiptCancel()
+	if err != nil {
+		w.log.Printf("failed to fetch receipt for reward tx %s: %v", txHash, err)
+	}
+	alertMsg := fmt.Sprintf(
+		tr("✅ Reward called for %s in round %d at block %d, [tx %s](https://arbiscan.io/tx/%s)."),
+		o.link(), w.currentRound, vLog.BlockNumber, txHash, txHash)
+	if minted != nil {
+		alertMsg += fmt.Sprintf(tr(" %s LPT minted%s."), formatUnits(minted, 18, 2), w.fiatValue(coinGeckoLPT, minted))
+		if len(w.orchestrators) > 1 {
+			total := new(big.Int)
+			for _, other := range w.orchestrators {
+				total.Add(total, other.roundMinted)
+			}
+			alertMsg += fmt.Sprintf(tr(" %s LPT minted by all watched orchestrators this round."), formatUnits(total, 18, 2))
+		}
+	}
+	if cut := treasuryCut(w.abis.BondingManager, w.net.Contracts.BondingManager, receipt, o.address); cut.Sign() > 0 {
+		o.roundTreasury.Add(o.roundTreasury, cut)
+		o.treasuryTotal.Add(o.treasuryTotal, cut)
+		alertMsg += fmt.Sprintf(tr(" Treasury contribution: %s LPT."), formatUnits(cut, 18, 4))
+	}
+	if receipt != nil && receipt.EffectiveGasPrice != nil {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		o.roundRewardFee.Add(o.roundRewardFee, fee)
+		alertMsg += fmt.Sprintf(tr(" Gas used: %d, fee: %s ETH%s."), receipt.GasUsed, formatUnits(fee, 18, 6), w.fiatValue(coinGeckoETH, fee))
+	}
+	w.log.Println(alertMsg)
+	if !w.opts.disableSuccessAlerts {
+		w.send(alert{
+			Type:         "reward",
+			Message:      alertMsg,
+			Color:        0x00FF00,
+			Round:        w.currentRound,
+			Orchestrator: o.address,
+			Block:        vLog.BlockNumber,
+			Tx:           txHash,
+		})
+	}
+	// Replayed events from before startup were exported by the previous run.
+	if w.exporter != nil && minted != nil && !w.silent {
+		w.exporter.exportEvent("reward", w.currentRound, minted, txHash)
+	}
+
+	// Compare gas used against recent reward calls.
+	if receipt == nil {
+		return
+	}
+	mean, anomalous := o.rewardGas.observe(receipt.GasUsed, w.opts.gasAnomalyThreshold)
+	if anomalous {
+		gasMsg := fmt.Sprintf(
+			tr("⛽ Reward call for %s in round %d used %d gas, %.0f%% off the recent average of %.0f gas, [tx %s](https://arbiscan.io/tx/%s)."),
+			o.link(), w.currentRound, receipt.GasUsed, (float64(receipt.GasUsed)-mean)/mean*100, mean, txHash, txHash)
+		w.log.Println(gasMsg)
+		w.send(alert{
+			Type:         "gas_anomaly",
+			Message:      gasMsg,
+			Color:        0xFFA500,
+			Round:        w.currentRound,
+			Orchestrator: o.address,
+			Block:        vLog.BlockNumber,
+			Tx:           txHash,
+		})
+	}
+}
+
+// handleWinningTicket processes a winning ticket redeemed by a watched orchestrator.
+func (w *watcher) handleWinningTicket(vLog types.Log) {
+	if len(vLog.Topics) < 3 {
+		return
+	}
+	o := w.orchestratorByTopic(vLog.Topics[2])
+	if o == nil {
+		return
+	}
+	values, err := w.abis.TicketBroker.Unpack("WinningTicketRedeemed", vLog.Data)
+	if err != nil || len(values) == 0 {
+		w.log.Printf("failed to decode WinningTicketRedeemed event: %v", err)
+		return
+	}
+	faceValue, ok := values[0].(*big.Int)
+	if !ok {
+		return
+	}
+	o.roundFees.Add(o.roundFees, faceValue)
+	o.roundTickets++
+	ticketEvent := w.logEvent(vLog, "WinningTicketRedeemed", o)
+	ticketEvent.Amount = formatUnits(faceValue, 18, 18)
+	w.publishEvent(ticketEvent)
+	w.log.Printf("Winning ticket redeemed by %s in round %d: %s ETH (tx %s)", o.address.Hex(), w.currentRound, formatUnits(faceValue, 18, 6), vLog.TxHash.Hex())
+	if w.exporter != nil && !w.silent {
+		w.exporter.exportEvent("fee", w.currentRound, faceValue, vLog.TxHash.Hex())
+	}
+}
+
+// handleNewRound summarizes the finished round and resets the round state.
+func (w *watcher) handleNewRound(vLog types.Log) {
+	var roundNum uint64
+	if len(vLog.Topics) > 1 {
+		roundNum = vLog.Topics[1].Big().Uint64()
+	}
+	if w.currentRound != 0 {
+		for _, o := range w.orchestrators {
+			summaryMsg := w.roundSummary(o)
+			w.log.Println(summaryMsg)
+			if !w.opts.disableRoundSummary {
+				w.orchestratorAlert(o, "round_summary", summaryMsg, 0x0099FF)
+			}
+		}
+	}
+	if w.roundStall.seen() {
+		w.log.Println("NewRound events are being observed again.")
+	}
+	for _, o := range w.orchestrators {
+		o.resetRound()
+		o.roundStartStake = w.transcoderTotalStake(o)
+	}
+	w.currentRound = roundNum
+	w.roundStart = w.eventTime(vLog)
+	w.publishEvent(w.logEvent(vLog, "NewRound", nil))
+	w.log.Printf("New round %d started", w.currentRound)
+	if !w.opts.disableRoundAlerts {
+		newRoundMsg := fmt.Sprintf(tr("🔄 New round %d started."), w.currentRound)
+		w.roundAlert("new_round", newRoundMsg, 0x0099FF)
+	}
+	if !w.catchingUp {
+		w.refreshDeactivationRounds()
+		w.checkDelegatorClaims()
+	}
+}
+
+// check runs the periodic stall, reward caller and missing reward checks.
+func (w *watcher) check() {
+	w.checkCallers()
+	if w.networkRewardStall.stalled() {
+		stallMsg := fmt.Sprintf(
+			tr("⚠️ No Reward events observed across the whole network for %s. This likely indicates an RPC problem or a protocol incident."),
+			formatDuration(w.opts.networkStallTimeout))
+		w.log.Println(stallMsg)
+		w.alert("network_reward_stall", stallMsg, 0xFFA500)
+	}
+	if w.roundStall.stalled() {
+		stallMsg := fmt.Sprintf(
+			tr("⚠️ No NewRound event observed for %s. This likely indicates an RPC problem or a protocol incident."),
+			formatDuration(w.opts.roundStallTimeout))
+		w.log.Println(stallMsg)
+		w.alert("round_stall", stallMsg, 0xFFA500)
+	}
+	if w.roundStart.IsZero() {
+		return
+	}
+	waited := formatDuration(w.opts.delay)
+	stage := 0
+	var position roundPosition
+	var positioned bool
+	if len(w.opts.warningStages) > 0 || w.opts.delayProgress > 0 {
+		if position, positioned = w.roundPosition(); !positioned {
+			return
+		}
+		progress := position.progress()
+		if len(w.opts.warningStages) > 0 {
+			if stage = warningStage(w.opts.warningStages, progress); stage == 0 {
+				return
+			}
+		} else if progress < w.opts.delayProgress {
+			return
+		}
+		waited = fmt.Sprintf(tr("%.0f%% of the round"), progress)
+	} else {
+		if time.Since(w.roundStart) < w.opts.delay {
+			return
+		}
+		position, positioned = w.roundPosition()
+	}
+	eta := ""
+	if positioned {
+		eta = fmt.Sprintf(tr(" Approximately %s remaining to call reward."), formatDuration(position.remaining().Round(time.Minute)))
+	}
+	for _, o := range w.orchestrators {
+		if o.rewardCalled {
+			continue
+		}
+		if tx := w.pendingRewardTx(o); tx != nil {
+			w.reportPendingReward(o, tx)
+			continue
+		}
+		o.pendingReward = pendingRewardState{}
+		if o.firstWarning.IsZero() {
+			o.firstWarning = time.Now()
+		}
+		var channels []string
+		escalated := false
+		if stages := w.net.Notifier.Escalation; len(stages) > 0 {
+			var level int
+			level, channels = escalationLevel(stages, time.Since(o.firstWarning))
+			escalated = level > o.escalationLevel
+			o.escalationLevel = level
+			if channels == nil {
+				channels = []string{}
+			}
+		}
+		staged := stage > o.warningStage
+		o.warningStage = stage
+		if o.sentWarning && !w.opts.repeat && !escalated && !staged {
+			continue
+		}
+		format, color, severity := tr("❌ No reward called for %s in round %d after %s."), 0xFF0000, ""
+		if stage > 0 {
+			format, color, severity = warningTier(stage, len(w.opts.warningStages))
+		}
+		alertMsg := fmt.Sprintf(format, o.link(), w.currentRound, waited) + eta
+		alertMsg += w.gasPriceDiagnosis()
+		alertMsg += w.simulateReward(o)
+		if escalated && o.escalationLevel > 1 {
+			alertMsg += fmt.Sprintf(tr(" Escalated to %s."), strings.Join(channels, ", "))
+		}
+		w.log.Println(alertMsg)
+		a := alert{
+			Type:         "reward_missed",
+			Message:      alertMsg,
+			Color:        color,
+			Severity:     severity,
+			Round:        w.currentRound,
+			Orchestrator: o.address,
+			Channels:     channels,
+		}
+		if severity == "" || severity == severityCritical {
+			w.incidentAlert(rewardIncident(o, w.currentRound, alertMsg), a)
+		} else if !w.silent {
+			// Early stages don't page yet.
+			w.send(a)
+		}
+		o.sentWarning = true
+		o.warnings++
+		if w.opts.voiceCallAfter > 0 && o.warnings == w.opts.voiceCallAfter && !w.silent {
+			w.net.Notifier.call(fmt.Sprintf(
+				"Livepeer reward watcher alert. Orchestrator %s has not called reward in round %d.",
+				spokenAddress(o), w.currentRound))
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webhookConfig configures the generic JSON webhook channel.
+type webhookConfig struct {
+	URL string `json:"url"`
+	// Secret signs every request with HMAC-SHA256 when set.
+	Secret string `json:"secret"`
+}
+
+var webhookHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// webhookReplayWindow is how far the timestamp of a signed request may be from the local clock.
+const webhookReplayWindow = 5 * time.Minute
+
+// signWebhook returns the hex HMAC-SHA256 signature of "<timestamp>.<body>" using secret.
+// Including the timestamp lets receivers reject replayed requests outside their accepted window.
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookVerifier checks signed requests and remembers the signatures it accepted, so a
+// captured request can't be replayed while its timestamp is still inside the replay window.
+type webhookVerifier struct {
+	secret string
+	mu     sync.Mutex
+	seen   map[string]time.Time // signature -> when it leaves the replay window
+}
+
+func newWebhookVerifier(secret string) *webhookVerifier {
+	return &webhookVerifier{secret: secret, seen: make(map[string]time.Time)}
+}
+
+// verify checks the X-Timestamp and X-Signature headers of a request against its raw body.
+func (v *webhookVerifier) verify(header http.Header, body []byte, now time.Time) error {
+	timestamp, err := strconv.ParseInt(header.Get("X-Timestamp"), 10, 64)
+	if err != nil {
+		return errors.New("missing or invalid X-Timestamp")
+	}
+	sent := time.Unix(timestamp, 0)
+	if sent.Before(now.Add(-webhookReplayWindow)) || sent.After(now.Add(webhookReplayWindow)) {
+		return errors.New("timestamp outside the replay window")
+	}
+	signature, ok := strings.CutPrefix(header.Get("X-Signature"), "sha256=")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signWebhook(v.secret, timestamp, body))) {
+		return errors.New("invalid signature")
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for s, expiry := range v.seen {
+		if now.After(expiry) {
+			delete(v.seen, s)
+		}
+	}
+	if _, ok := v.seen[signature]; ok {
+		return errors.New("request was already used")
+	}
+	v.seen[signature] = sent.Add(webhookReplayWindow)
+	return nil
+}
+
+// sendWebhookAlert posts an alert as JSON to the generic webhook, signing it if a secret is set.
+func sendWebhookAlert(cfg webhookConfig, payload event) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Secret != "" {
+		req.Header.Set("X-Timestamp", strconv.FormatInt(payload.Timestamp, 10))
+		req.Header.Set("X-Signature", "sha256="+signWebhook(cfg.Secret, payload.Timestamp, body))
+	}
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
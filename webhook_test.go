@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"command":"status"}`)
+	signed := func(secret string, sent time.Time, body []byte) http.Header {
+		h := http.Header{}
+		h.Set("X-Timestamp", strconv.FormatInt(sent.Unix(), 10))
+		h.Set("X-Signature", "sha256="+signWebhook(secret, sent.Unix(), body))
+		return h
+	}
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    []byte
+		wantErr string
+	}{
+		{"valid", signed("secret", now, body), body, ""},
+		{"inside the window", signed("secret", now.Add(-4*time.Minute), body), body, ""},
+		{"stale", signed("secret", now.Add(-6*time.Minute), body), body, "replay window"},
+		{"from the future", signed("secret", now.Add(6*time.Minute), body), body, "replay window"},
+		{"wrong secret", signed("other", now, body), body, "invalid signature"},
+		{"modified body", signed("secret", now, body), []byte(`{"command":"mute","arg":"6h"}`), "invalid signature"},
+		{"missing timestamp", http.Header{"X-Signature": {"sha256=00"}}, body, "X-Timestamp"},
+		{"missing signature", http.Header{"X-Timestamp": {strconv.FormatInt(now.Unix(), 10)}}, body, "invalid signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newWebhookVerifier("secret").verify(tt.header, tt.body, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("verify() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("verify() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookVerifierRejectsReuse(t *testing.T) {
+	v := newWebhookVerifier("secret")
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"command":"unmute"}`)
+	h := http.Header{}
+	h.Set("X-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	h.Set("X-Signature", "sha256="+signWebhook("secret", now.Unix(), body))
+	if err := v.verify(h, body, now); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if err := v.verify(h, body, now.Add(time.Minute)); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("replayed request: got %v, want it rejected as already used", err)
+	}
+	if len(v.seen) != 1 {
+		t.Fatalf("remembered %d signatures, want 1", len(v.seen))
+	}
+	// Signatures are forgotten once their timestamp has left the replay window.
+	other := []byte(`{"command":"status"}`)
+	later := now.Add(10 * time.Minute)
+	h.Set("X-Timestamp", strconv.FormatInt(later.Unix(), 10))
+	h.Set("X-Signature", "sha256="+signWebhook("secret", later.Unix(), other))
+	if err := v.verify(h, other, later); err != nil {
+		t.Fatalf("later request: %v", err)
+	}
+	if len(v.seen) != 1 {
+		t.Fatalf("remembered %d signatures after expiry, want 1", len(v.seen))
+	}
+}
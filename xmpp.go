@@ -0,0 +1,196 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// xmppConfig configures the XMPP alert channel.
+type xmppConfig struct {
+	// Server is the host:port to connect to (default: the JID's domain on port 5222).
+	Server     string   `json:"server"`
+	JID        string   `json:"jid"`
+	Password   string   `json:"password"`
+	Recipients []string `json:"recipients"`
+	// CAFile is a PEM CA bundle trusted in addition to the system roots, e.g. for a server with a
+	// self-signed certificate.
+	CAFile string `json:"caFile"`
+}
+
+func (c xmppConfig) complete() bool {
+	return c.JID != "" && c.Password != "" && len(c.Recipients) > 0
+}
+
+// tlsConfig returns the TLS config for STARTTLS with the server of domain.
+func (c xmppConfig) tlsConfig(domain string) (*tls.Config, error) {
+	cfg, err := loadClientTLS("", "", c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	cfg.ServerName = domain
+	return cfg, nil
+}
+
+// xmppFeatures are the stream features announced by the server.
+type xmppFeatures struct {
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms []string  `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms>mechanism"`
+	Bind       *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+}
+
+// xmppConn is a client-to-server XMPP stream.
+type xmppConn struct {
+	conn   net.Conn
+	dec    *xml.Decoder
+	domain string
+}
+
+// nextElement returns the next top-level element of the stream.
+func (c *xmppConn) nextElement() (xml.StartElement, error) {
+	for {
+		tok, err := c.dec.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// expect reads the next element and fails unless it is named local.
+func (c *xmppConn) expect(local string) error {
+	start, err := c.nextElement()
+	if err != nil {
+		return err
+	}
+	if start.Name.Local != local {
+		return fmt.Errorf("expected <%s>, got <%s>", local, start.Name.Local)
+	}
+	return c.dec.Skip()
+}
+
+// openStream starts a new stream, as required after connecting, STARTTLS and authentication,
+// and returns the server's stream features.
+func (c *xmppConn) openStream() (xmppFeatures, error) {
+	var features xmppFeatures
+	c.dec = xml.NewDecoder(c.conn)
+	if _, err := fmt.Fprintf(c.conn,
+		"<?xml version='1.0'?><stream:stream to='%s' xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' version='1.0'>",
+		xmlEscape(c.domain)); err != nil {
+		return features, err
+	}
+	start, err := c.nextElement()
+	if err != nil {
+		return features, err
+	}
+	if start.Name.Local != "stream" {
+		return features, fmt.Errorf("unexpected <%s> instead of stream header", start.Name.Local)
+	}
+	if start, err = c.nextElement(); err != nil {
+		return features, err
+	}
+	if start.Name.Local != "features" {
+		return features, fmt.Errorf("unexpected <%s> instead of stream features", start.Name.Local)
+	}
+	err = c.dec.DecodeElement(&features, &start)
+	return features, err
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// sendXMPPAlert logs in with the JID over STARTTLS and SASL PLAIN and sends the alert as a chat
+// message to every recipient.
+func sendXMPPAlert(cfg xmppConfig, message string) error {
+	local, domain, ok := strings.Cut(cfg.JID, "@")
+	if !ok {
+		return fmt.Errorf("invalid JID %q", cfg.JID)
+	}
+	domain, _, _ = strings.Cut(domain, "/")
+	server := cfg.Server
+	if server == "" {
+		server = net.JoinHostPort(domain, "5222")
+	}
+	tlsConfig, err := cfg.tlsConfig(domain)
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", server, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	c := &xmppConn{conn: conn, domain: domain}
+
+	features, err := c.openStream()
+	if err != nil {
+		return err
+	}
+	if features.StartTLS == nil {
+		return fmt.Errorf("server %s does not offer STARTTLS", server)
+	}
+	fmt.Fprint(c.conn, "<starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>")
+	if err := c.expect("proceed"); err != nil {
+		return fmt.Errorf("STARTTLS failed: %v", err)
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("TLS handshake failed: %v", err)
+	}
+	c.conn = tlsConn
+
+	if features, err = c.openStream(); err != nil {
+		return err
+	}
+	plain := false
+	for _, m := range features.Mechanisms {
+		plain = plain || m == "PLAIN"
+	}
+	if !plain {
+		return fmt.Errorf("server %s does not offer SASL PLAIN authentication", server)
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + local + "\x00" + cfg.Password))
+	fmt.Fprintf(c.conn, "<auth xmlns='urn:ietf:params:xml:ns:xmpp-sasl' mechanism='PLAIN'>%s</auth>", credentials)
+	if err := c.expect("success"); err != nil {
+		return fmt.Errorf("authentication failed: %v", err)
+	}
+
+	if _, err = c.openStream(); err != nil {
+		return err
+	}
+	fmt.Fprint(c.conn, "<iq type='set' id='bind1'><bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'><resource>reward-watcher</resource></bind></iq>")
+	start, err := c.nextElement()
+	if err != nil {
+		return fmt.Errorf("resource binding failed: %v", err)
+	}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "type" && attr.Value != "result" {
+			return fmt.Errorf("resource binding failed: got iq of type %q", attr.Value)
+		}
+	}
+	if err := c.dec.Skip(); err != nil {
+		return err
+	}
+
+	body := xmlEscape(markdownLinkRe.ReplaceAllString(message, "$1 ($2)"))
+	for _, to := range cfg.Recipients {
+		if _, err := fmt.Fprintf(c.conn, "<message to='%s' type='chat'><body>%s</body></message>", xmlEscape(to), body); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprint(c.conn, "</stream:stream>")
+	return err
+}
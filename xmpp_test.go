@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// selfSignedCert returns a certificate for localhost and the path of a CA file trusting it.
+func selfSignedCert(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, caFile
+}
+
+// xmppMessage is a chat message received by the fake XMPP server.
+type xmppMessage struct {
+	To   string `xml:"to,attr"`
+	Body string `xml:"body"`
+}
+
+// fakeXMPPServer accepts one client, offers STARTTLS with cert, accepts SASL PLAIN for
+// watcher/password and binds a resource. It returns the address to connect to and a channel
+// that receives the messages of the client, or the error that ended the session.
+func fakeXMPPServer(t *testing.T, cert tls.Certificate) (string, <-chan interface{}) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	result := make(chan interface{}, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			result <- err
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(10 * time.Second))
+		messages, err := serveXMPP(conn, cert)
+		if err != nil {
+			result <- err
+			return
+		}
+		result <- messages
+	}()
+	return ln.Addr().String(), result
+}
+
+func serveXMPP(conn net.Conn, cert tls.Certificate) ([]xmppMessage, error) {
+	var dec *xml.Decoder
+	next := func() (xml.StartElement, error) {
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return xml.StartElement{}, err
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				return start, nil
+			}
+		}
+	}
+	// openStream answers the client's stream header with the given stream features.
+	openStream := func(features string) error {
+		dec = xml.NewDecoder(conn)
+		if start, err := next(); err != nil || start.Name.Local != "stream" {
+			return fmt.Errorf("expected a stream header, got <%s>: %v", start.Name.Local, err)
+		}
+		_, err := fmt.Fprintf(conn, "<?xml version='1.0'?><stream:stream from='localhost' id='1' xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' version='1.0'><stream:features>%s</stream:features>", features)
+		return err
+	}
+
+	if err := openStream("<starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'><required/></starttls>"); err != nil {
+		return nil, err
+	}
+	if start, err := next(); err != nil || start.Name.Local != "starttls" {
+		return nil, fmt.Errorf("expected <starttls>, got <%s>: %v", start.Name.Local, err)
+	}
+	fmt.Fprint(conn, "<proceed xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>")
+	tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	conn = tlsConn
+
+	if err := openStream("<mechanisms xmlns='urn:ietf:params:xml:ns:xmpp-sasl'><mechanism>SCRAM-SHA-1</mechanism><mechanism>PLAIN</mechanism></mechanisms>"); err != nil {
+		return nil, err
+	}
+	start, err := next()
+	if err != nil {
+		return nil, err
+	}
+	var auth struct {
+		Mechanism string `xml:"mechanism,attr"`
+		Value     string `xml:",chardata"`
+	}
+	if err := dec.DecodeElement(&auth, &start); err != nil {
+		return nil, err
+	}
+	credentials, _ := base64.StdEncoding.DecodeString(auth.Value)
+	if auth.Mechanism != "PLAIN" || string(credentials) != "\x00watcher\x00password" {
+		fmt.Fprint(conn, "<failure xmlns='urn:ietf:params:xml:ns:xmpp-sasl'><not-authorized/></failure>")
+		return nil, fmt.Errorf("rejected %s credentials %q", auth.Mechanism, credentials)
+	}
+	fmt.Fprint(conn, "<success xmlns='urn:ietf:params:xml:ns:xmpp-sasl'/>")
+
+	if err := openStream("<bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'/>"); err != nil {
+		return nil, err
+	}
+	if start, err := next(); err != nil || start.Name.Local != "iq" {
+		return nil, fmt.Errorf("expected a bind <iq>, got <%s>: %v", start.Name.Local, err)
+	}
+	if err := dec.Skip(); err != nil {
+		return nil, err
+	}
+	fmt.Fprint(conn, "<iq type='result' id='bind1'><bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'><jid>watcher@localhost/reward-watcher</jid></bind></iq>")
+
+	var messages []xmppMessage
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			var m xmppMessage
+			if err := dec.DecodeElement(&m, &tok); err != nil {
+				return nil, err
+			}
+			messages = append(messages, m)
+		case xml.EndElement:
+			// The client closed the stream.
+			return messages, nil
+		}
+	}
+}
+
+func TestSendXMPPAlert(t *testing.T) {
+	cert, caFile := selfSignedCert(t)
+	tests := []struct {
+		name    string
+		cfg     xmppConfig
+		want    []xmppMessage
+		wantErr string
+	}{
+		{
+			name: "logs in and messages every recipient",
+			cfg:  xmppConfig{JID: "watcher@localhost", Password: "password", Recipients: []string{"alice@localhost", "bob@localhost"}, CAFile: caFile},
+			want: []xmppMessage{
+				{To: "alice@localhost", Body: "⚠️ Reward <not> called, see explorer (https://explorer.livepeer.org)"},
+				{To: "bob@localhost", Body: "⚠️ Reward <not> called, see explorer (https://explorer.livepeer.org)"},
+			},
+		},
+		{
+			name:    "rejects a certificate that isn't trusted",
+			cfg:     xmppConfig{JID: "watcher@localhost", Password: "password", Recipients: []string{"alice@localhost"}},
+			wantErr: "TLS handshake failed",
+		},
+		{
+			name:    "reports a wrong password",
+			cfg:     xmppConfig{JID: "watcher@localhost", Password: "wrong", Recipients: []string{"alice@localhost"}, CAFile: caFile},
+			wantErr: "authentication failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, result := fakeXMPPServer(t, cert)
+			tt.cfg.Server = addr
+			err := sendXMPPAlert(tt.cfg, "⚠️ Reward <not> called, see [explorer](https://explorer.livepeer.org)")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("sendXMPPAlert() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch got := (<-result).(type) {
+			case error:
+				t.Fatalf("server: %v", got)
+			case []xmppMessage:
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("messages = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultZulipTopic = "reward watcher"
+
+// zulipConfig configures the Zulip alert channel.
+type zulipConfig struct {
+	Site     string `json:"site"`
+	BotEmail string `json:"botEmail"`
+	APIKey   string `json:"apiKey"`
+	Stream   string `json:"stream"`
+	// Topic receives alerts that don't belong to a round (default: "reward watcher").
+	Topic string `json:"topic"`
+}
+
+func (c zulipConfig) complete() bool {
+	return c.Site != "" && c.BotEmail != "" && c.APIKey != "" && c.Stream != ""
+}
+
+// sendZulipAlert posts an alert to a Zulip stream. Alerts of a round go to a "Round N" topic, so
+// each round reads as its own thread.
+func sendZulipAlert(cfg zulipConfig, message string, round uint64) error {
+	topic := cfg.Topic
+	if topic == "" {
+		topic = defaultZulipTopic
+	}
+	if round != 0 {
+		topic = fmt.Sprintf("Round %d", round)
+	}
+	form := url.Values{
+		"type":    {"stream"},
+		"to":      {cfg.Stream},
+		"topic":   {topic},
+		"content": {message},
+	}
+	endpoint := strings.TrimRight(cfg.Site, "/") + "/api/v1/messages"
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(cfg.BotEmail, cfg.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("zulip API returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}